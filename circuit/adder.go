@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
-	"sync"
 )
 
 // Half Adder
@@ -27,6 +26,22 @@ func newHalfAdder(pin1, pin2 emitter) *halfAdder {
 	}
 }
 
+func (h *halfAdder) ports() []namedPart {
+	return []namedPart{
+		{"pin1", portOf(h.sum, "pin1")},
+		{"pin2", portOf(h.sum, "pin2")},
+		{"sum", h.sum},
+		{"carry", h.carry},
+	}
+}
+
+func (h *halfAdder) parts() []namedPart {
+	return []namedPart{
+		{"sum", h.sum},
+		{"carry", h.carry},
+	}
+}
+
 // Full Adder
 // A, B, and Carry in result in Sum and Carry out (handles 1 + 1 + 'carried over + 1')
 
@@ -48,179 +63,326 @@ func newFullAdder(pin1, pin2, carryIn emitter) *fullAdder {
 	return f
 }
 
-// 8-bit Adder
-// Handles a Carry bit in and holds potential Carry bit after summing all
-//    10011101
-// +  11010110
-// = 101110011
+func (f *fullAdder) ports() []namedPart {
+	return []namedPart{
+		{"pin1", portOf(f.halfAdder1, "pin1")},
+		{"pin2", portOf(f.halfAdder1, "pin2")},
+		{"carryIn", portOf(f.halfAdder2, "pin2")},
+		{"sum", f.sum},
+		{"carry", f.carry},
+	}
+}
 
-type EightBitAdder struct {
-	fullAdders [8]*fullAdder
+func (f *fullAdder) parts() []namedPart {
+	return []namedPart{
+		{"halfAdder1", f.halfAdder1},
+		{"halfAdder2", f.halfAdder2},
+		{"sum", f.sum},
+		{"carry", f.carry},
+	}
+}
+
+// BinaryAdder is what every adder architecture (ripple carry, carry-lookahead, carry-select) has in common, so they can be swapped for one another
+type BinaryAdder interface {
+	outputter
+	Width() int
+	Sum() *Bus
+	CarryOut() emitter
+	String() string
+}
+
+// N-bit Adder
+// A chain of full adders of any width, each one's carry out rippling into the carry in of its neighbor to the left.  Handles a Carry bit in from the far
+// right and holds potential Carry bit after summing all the bits
+//    1001
+// +  1011
+// = 10100
+
+type Adder struct {
+	name string // what the inputs are called in port names, e.g. "byte" makes "byte1[3]"
+	switchInputs
+	fullAdders []*fullAdder
 	carryOut   emitter
 }
 
-func NewEightBitAdder(byte1, byte2 string, carryIn emitter) (*EightBitAdder, error) {
-	match, err := regexp.MatchString("^[01]{8}$", byte1)
+// NewAdder builds an adder of any width (at least 1 bit) from two strings of that many 0s and 1s
+func NewAdder(width int, bits1, bits2 string, carryIn emitter) (*Adder, error) {
+	return newNamedAdder("bits", width, bits1, bits2, carryIn)
+}
+
+func newNamedAdder(name string, width int, bits1, bits2 string, carryIn emitter) (*Adder, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Adder width must be at least 1, but got ", width))
+	}
+
+	a := &Adder{name: name, fullAdders: make([]*fullAdder, width)}
+
+	bits1, bits2, err := parseOperandPair(a.Width(), bits1, bits2)
 	if err != nil {
 		return nil, err
 	}
-	if !match {
-		err = errors.New(fmt.Sprint("First input not in 8-bit binary format: " + byte1))
+
+	if err := a.validateInputs(bits1, bits2); err != nil {
 		return nil, err
 	}
 
-	match, err = regexp.MatchString("^[01]{8}$", byte2)
-	if err != nil {
-		return nil, err
+	a.bits1Switches = newSwitchBusFromBits(bits1)
+	a.bits2Switches = newSwitchBusFromBits(bits2)
+
+	a.build(a.bits1Switches, a.bits2Switches, carryIn)
+
+	return a, nil
+}
+
+// NewAdderFromBuses builds an adder as wide as the two Buses, wired directly to them (e.g. the Sum of another adder), so it follows any change on them
+func NewAdderFromBuses(bus1, bus2 *Bus, carryIn emitter) (*Adder, error) {
+	if bus1.Width() != bus2.Width() {
+		return nil, errors.New(fmt.Sprintf("Inputs are not the same width: %d and %d bits", bus1.Width(), bus2.Width()))
 	}
 
-	if !match {
-		err = errors.New(fmt.Sprint("Second input not in 8-bit binary format: " + byte2))
-		return nil, err
+	if bus1.Width() < 1 {
+		return nil, errors.New(fmt.Sprint("Adder width must be at least 1, but got ", bus1.Width()))
 	}
 
-	a := &EightBitAdder{}
+	return newNamedAdderFromBuses("bits", bus1, bus2, carryIn), nil
+}
 
-	for i := 7; i >= 0; i-- {
-		var f *fullAdder
-		var pin1 emitter
-		var pin2 emitter
-
-		switch byte1[i] {
-		case '0':
-			pin1 = nil
-		case '1':
-			pin1 = &Battery{}
-		}
+func newNamedAdderFromBuses(name string, bus1, bus2 *Bus, carryIn emitter) *Adder {
+	a := &Adder{name: name, fullAdders: make([]*fullAdder, bus1.Width())}
 
-		switch byte2[i] {
-		case '0':
-			pin2 = nil
-		case '1':
-			pin2 = &Battery{}
-		}
+	a.build(bus1, bus2, carryIn)
+
+	return a
+}
+
+func (a *Adder) build(bus1, bus2 *Bus, carryIn emitter) {
+	last := len(a.fullAdders) - 1
 
-		if i == 7 {
-			f = newFullAdder(pin1, pin2, carryIn)
+	for i := last; i >= 0; i-- {
+		var f *fullAdder
+
+		if i == last {
+			f = newFullAdder(bus1.wires[i], bus2.wires[i], carryIn)
 		} else {
-			f = newFullAdder(pin1, pin2, a.fullAdders[i+1].carry) // carry-in is the neighboring adders carry-out
+			f = newFullAdder(bus1.wires[i], bus2.wires[i], a.fullAdders[i+1].carry) // carry-in is the neighboring adders carry-out
 		}
 
 		a.fullAdders[i] = f
 	}
 
 	a.carryOut = a.fullAdders[0].carry
+}
 
-	return a, nil
+// Width is how many bits the adder sums (not counting the carry)
+func (a *Adder) Width() int {
+	return len(a.fullAdders)
 }
 
-func (a *EightBitAdder) String() string {
-	answer := ""
+// UpdateInputs flips the input switches to match the new bits, so the existing adder settles on the new answer without being rebuilt
+func (a *Adder) UpdateInputs(bits1, bits2 string) error {
+	return a.updateSwitches("Adder", a.Width(), bits1, bits2)
+}
 
-	if a.carryOut.Emitting() {
-		answer += "1"
+// Sum returns the sum bits as a Bus that can be wired into other circuits
+func (a *Adder) Sum() *Bus {
+	b := &Bus{}
+
+	for _, f := range a.fullAdders {
+		b.wires = append(b.wires, f.sum)
 	}
 
-	for _, v := range a.fullAdders {
+	return b
+}
 
-		if v.sum.Emitting() {
-			answer += "1"
-		} else {
-			answer += "0"
-		}
+// CarryOut returns the carry out of the leftmost full adder so it can be wired into other circuits
+func (a *Adder) CarryOut() emitter {
+	return a.carryOut
+}
+
+func (a *Adder) ports() []namedPart {
+	var ports []namedPart
+
+	for i, f := range a.fullAdders {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("%s1[%d]", a.name, i), portOf(f, "pin1")},
+			namedPart{fmt.Sprintf("%s2[%d]", a.name, i), portOf(f, "pin2")},
+			namedPart{fmt.Sprintf("sum[%d]", i), f.sum})
 	}
 
-	return answer
+	return append(ports,
+		namedPart{"carryIn", portOf(a.fullAdders[len(a.fullAdders)-1], "carryIn")},
+		namedPart{"carryOut", a.carryOut})
 }
 
-// 16-bit Adder
-// Handles a Carry bit in from the far right, chains the two, inner half-adder on a Carry, and holds potential Carry bit after summing all 16 bits
-//    1001110110011101
-// +  1101011011010110
-// = 10111010001110011
+func (a *Adder) parts() []namedPart {
+	parts := a.switchParts(a.name)
 
-type SixteenBitAdder struct {
-	rightAdder *EightBitAdder
-	leftAdder  *EightBitAdder
-	carryOut   emitter
+	for i := range a.fullAdders {
+		parts = append(parts, namedPart{fmt.Sprintf("fullAdders[%d]", i), a.fullAdders[i]})
+	}
+
+	return append(parts, namedPart{"carryOut", a.carryOut})
 }
 
-func NewSixteenBitAdder(bytes1, bytes2 string, carryIn emitter) (*SixteenBitAdder, error) {
-	match, err := regexp.MatchString("^[01]{16}$", bytes1)
+func (a *Adder) outputs() []namedPart {
+	parts := []namedPart{{"carryOut", a.carryOut}}
+
+	for i, f := range a.fullAdders {
+		parts = append(parts, namedPart{fmt.Sprintf("fullAdders[%d].sum", i), f.sum})
+	}
+
+	return parts
+}
+
+func (a *Adder) validateInputs(bits1, bits2 string) error {
+	return validateBits(len(a.fullAdders), bits1, bits2)
+}
+
+func (a *Adder) String() string {
+	return sumString(a)
+}
+
+// validateBits makes sure both inputs of an adder (of any architecture) are strings of exactly width 0s and 1s
+func validateBits(width int, bits1, bits2 string) error {
+	format := fmt.Sprintf("^[01]{%d}$", width)
+
+	match, err := regexp.MatchString(format, bits1)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if !match {
-		err = errors.New(fmt.Sprint("First input not in 16-bit binary format: " + bytes1))
-		return nil, err
+		return errors.New(fmt.Sprintf("First input not in %d-bit binary format: %s", width, bits1))
 	}
 
-	match, err = regexp.MatchString("^[01]{16}$", bytes2)
+	match, err = regexp.MatchString(format, bits2)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if !match {
-		err = errors.New(fmt.Sprint("Second input not in 16-bit binary format: " + bytes2))
-		return nil, err
+		return errors.New(fmt.Sprintf("Second input not in %d-bit binary format: %s", width, bits2))
 	}
 
-	a := &SixteenBitAdder{}
+	return nil
+}
+
+// switchInputs are the two rows of switches a two input circuit owns when it's built from strings of bits, both nil when it's built from Buses instead
+// (since then the inputs belong to whatever drives them)
+type switchInputs struct {
+	bits1Switches *Bus
+	bits2Switches *Bus
+}
+
+// updateSwitches checks two new strings of width bits (or number literals, see Operands) and flips the switches to match them all at once, so the
+// circuit settles on its new answer without being rebuilt
+func (s *switchInputs) updateSwitches(circuit string, width int, bits1, bits2 string) error {
+	if s.bits1Switches == nil {
+		return errors.New(circuit + " inputs are driven by Buses, not switches, so they cannot be updated")
+	}
 
-	a.rightAdder, err = NewEightBitAdder(bytes1[8:], bytes2[8:], carryIn)
+	bits1, bits2, err := parseOperandPair(width, bits1, bits2)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if err := validateBits(width, bits1, bits2); err != nil {
+		return err
+	}
+
+	Simultaneously(func() {
+		s.bits1Switches.setBits(bits1)
+		s.bits2Switches.setBits(bits2)
+	})
+
+	return nil
+}
+
+// switchParts names the switches (if there are any) for a circuit's parts, e.g. "bits" makes "bits1Switches[3]"
+func (s *switchInputs) switchParts(name string) []namedPart {
+	var parts []namedPart
+
+	if s.bits1Switches != nil {
+		for i := range s.bits1Switches.wires {
+			parts = append(parts,
+				namedPart{fmt.Sprintf("%s1Switches[%d]", name, i), s.bits1Switches.wires[i]},
+				namedPart{fmt.Sprintf("%s2Switches[%d]", name, i), s.bits2Switches.wires[i]})
+		}
+	}
+
+	return parts
+}
+
+// sumString is the answer of an adder (of any architecture), with the carry only shown when it isn't 0
+func sumString(a BinaryAdder) string {
+	answer := ""
+
+	if a.CarryOut().Signal() != Low {
+		answer += a.CarryOut().Signal().String()
 	}
 
-	a.leftAdder, err = NewEightBitAdder(bytes1[:8], bytes2[:8], a.rightAdder.carryOut)
+	return answer + a.Sum().String()
+}
+
+// 8-bit Adder
+// Handles a Carry bit in and holds potential Carry bit after summing all
+//    10011101
+// +  11010110
+// = 101110011
+
+type EightBitAdder struct {
+	*Adder
+}
+
+func NewEightBitAdder(byte1, byte2 string, carryIn emitter) (*EightBitAdder, error) {
+	a, err := newNamedAdder("byte", 8, byte1, byte2, carryIn)
 	if err != nil {
 		return nil, err
 	}
 
-	a.carryOut = a.leftAdder.carryOut
-
-	return a, nil
+	return &EightBitAdder{a}, nil
 }
 
-func (a *SixteenBitAdder) String() string {
-	answerCarry := ""
-	answerLeft := ""
-	answerRight := ""
+// NewEightBitAdderFromBuses builds an adder wired directly to two 8-bit Buses (e.g. the Sum of another adder), so it follows any change on them
+func NewEightBitAdderFromBuses(bus1, bus2 *Bus, carryIn emitter) (*EightBitAdder, error) {
+	if bus1.Width() != 8 {
+		return nil, errors.New(fmt.Sprint("First input not an 8-bit Bus, width: ", bus1.Width()))
+	}
 
-	if a.leftAdder.carryOut.Emitting() {
-		answerCarry += "1"
+	if bus2.Width() != 8 {
+		return nil, errors.New(fmt.Sprint("Second input not an 8-bit Bus, width: ", bus2.Width()))
 	}
 
-	wg := &sync.WaitGroup{}
+	return &EightBitAdder{newNamedAdderFromBuses("byte", bus1, bus2, carryIn)}, nil
+}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
+// 16-bit Adder
+// Handles a Carry bit in from the far right and holds potential Carry bit after summing all 16 bits
+//    1001110110011101
+// +  1101011011010110
+// = 10111010001110011
 
-		for _, v := range a.leftAdder.fullAdders {
+type SixteenBitAdder struct {
+	*Adder
+}
 
-			if v.sum.Emitting() {
-				answerLeft += "1"
-			} else {
-				answerLeft += "0"
-			}
-		}
-	}()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		for _, v := range a.rightAdder.fullAdders {
-
-			if v.sum.Emitting() {
-				answerRight += "1"
-			} else {
-				answerRight += "0"
-			}
-		}
-	}()
+func NewSixteenBitAdder(bytes1, bytes2 string, carryIn emitter) (*SixteenBitAdder, error) {
+	a, err := newNamedAdder("bytes", 16, bytes1, bytes2, carryIn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SixteenBitAdder{a}, nil
+}
+
+// NewSixteenBitAdderFromBuses builds an adder wired directly to two 16-bit Buses (e.g. the Sum of another adder), so it follows any change on them
+func NewSixteenBitAdderFromBuses(bus1, bus2 *Bus, carryIn emitter) (*SixteenBitAdder, error) {
+	if bus1.Width() != 16 {
+		return nil, errors.New(fmt.Sprint("First input not a 16-bit Bus, width: ", bus1.Width()))
+	}
 
-	wg.Wait()
+	if bus2.Width() != 16 {
+		return nil, errors.New(fmt.Sprint("Second input not a 16-bit Bus, width: ", bus2.Width()))
+	}
 
-	return answerCarry + answerLeft + answerRight
+	return &SixteenBitAdder{newNamedAdderFromBuses("bytes", bus1, bus2, carryIn)}, nil
 }
@@ -0,0 +1,173 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Adder/Subtractor
+// One adder plus a ones complementer, both driven by a live SUB line (as in Petzold's "Code").  With SUB off the second input passes straight through
+// and the two are added.  With SUB on the second input is complemented and SUB also feeds the adder's carry in (the "+1" of the two's complement), so
+// the second input is subtracted from the first instead.  Flipping SUB changes the operation without building a new circuit
+//
+// SUB  carry out   carry or borrow
+// 0    0           0     (fits)
+// 0    1           1     (carried past the leftmost bit)
+// 1    1           0     (fits)
+// 1    0           1     (had to borrow)
+
+type AdderSubtractor struct {
+	switchInputs
+	subSwitch     *Switch
+	sub           emitter
+	comp          *onesComplementer
+	adder         *Adder
+	carryOrBorrow *xorGate
+	overflow      *xorGate
+}
+
+// NewAdderSubtractor builds an adder/subtractor of any width (at least 1 bit) from two strings of that many 0s and 1s, along with a SUB switch that can
+// be flipped later via SetSub
+func NewAdderSubtractor(width int, bits1, bits2 string, sub bool) (*AdderSubtractor, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Adder/Subtractor width must be at least 1, but got ", width))
+	}
+
+	bits1, bits2, err := parseOperandPair(width, bits1, bits2)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBits(width, bits1, bits2); err != nil {
+		return nil, err
+	}
+
+	a := &AdderSubtractor{}
+
+	a.bits1Switches = newSwitchBusFromBits(bits1)
+	a.bits2Switches = newSwitchBusFromBits(bits2)
+	a.subSwitch = NewSwitch(sub)
+
+	a.build(a.bits1Switches, a.bits2Switches, a.subSwitch)
+
+	return a, nil
+}
+
+// NewAdderSubtractorFromBuses builds an adder/subtractor as wide as the two Buses, wired directly to them and to whatever drives the SUB line, so it
+// follows any change on any of them
+func NewAdderSubtractorFromBuses(bus1, bus2 *Bus, sub emitter) (*AdderSubtractor, error) {
+	if bus1.Width() != bus2.Width() {
+		return nil, errors.New(fmt.Sprintf("Inputs are not the same width: %d and %d bits", bus1.Width(), bus2.Width()))
+	}
+
+	if bus1.Width() < 1 {
+		return nil, errors.New(fmt.Sprint("Adder/Subtractor width must be at least 1, but got ", bus1.Width()))
+	}
+
+	a := &AdderSubtractor{}
+
+	a.build(bus1, bus2, sub)
+
+	return a, nil
+}
+
+func (a *AdderSubtractor) build(bus1, bus2 *Bus, sub emitter) {
+	a.sub = sub
+	a.comp = NewOnesComplementerFromBus(bus2, sub)
+	a.adder = newNamedAdderFromBuses("bits", bus1, a.comp.ComplementBus(), sub)
+
+	a.carryOrBorrow = newXORGate(a.adder.carryOut, sub)
+
+	msbCarryIn := sub // the carry into the leftmost bit, which for a 1-bit adder/subtractor is SUB itself
+	if a.Width() > 1 {
+		msbCarryIn = a.adder.fullAdders[1].carry
+	}
+	a.overflow = newXORGate(msbCarryIn, a.adder.carryOut)
+}
+
+// Width is how many bits the adder/subtractor works on
+func (a *AdderSubtractor) Width() int {
+	return a.adder.Width()
+}
+
+// SetSub flips the SUB switch, subtracting when on and adding when off
+func (a *AdderSubtractor) SetSub(sub bool) error {
+	if a.subSwitch == nil {
+		return errors.New("SUB line is driven from outside, not by a switch, so it cannot be set")
+	}
+
+	a.subSwitch.Set(sub)
+
+	return nil
+}
+
+// Subtracting is true while the SUB line is on
+func (a *AdderSubtractor) Subtracting() bool {
+	return signalOf(a.sub) == High
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing adder/subtractor settles on the new answer without being rebuilt
+func (a *AdderSubtractor) UpdateInputs(bits1, bits2 string) error {
+	return a.updateSwitches("Adder/Subtractor", a.Width(), bits1, bits2)
+}
+
+// Result returns the sum or difference bits (without the carry out) as a Bus that can be wired into other circuits
+func (a *AdderSubtractor) Result() *Bus {
+	return a.adder.Sum()
+}
+
+// CarryOut returns the raw carry out of the inner adder so it can be wired into other circuits
+func (a *AdderSubtractor) CarryOut() emitter {
+	return a.adder.carryOut
+}
+
+// CarryOrBorrow is true when the answer (read unsigned) didn't fit: a carry out when adding, or a borrow when subtracting
+func (a *AdderSubtractor) CarryOrBorrow() bool {
+	return a.carryOrBorrow.Emitting()
+}
+
+// Overflow is true when the answer (read signed) didn't fit, e.g. 127 + 1 or -128 - 1 in 8 bits
+func (a *AdderSubtractor) Overflow() bool {
+	return a.overflow.Emitting()
+}
+
+func (a *AdderSubtractor) ports() []namedPart {
+	ports := []namedPart{{"sub", a.sub}}
+
+	for i := 0; i < a.Width(); i++ {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("bits1[%d]", i), portOf(a.adder, fmt.Sprintf("bits1[%d]", i))},
+			namedPart{fmt.Sprintf("bits2[%d]", i), portOf(a.comp, fmt.Sprintf("bits[%d]", i))},
+			namedPart{fmt.Sprintf("result[%d]", i), portOf(a.adder, fmt.Sprintf("sum[%d]", i))})
+	}
+
+	return append(ports,
+		namedPart{"carryOut", a.adder.carryOut},
+		namedPart{"carryOrBorrow", a.carryOrBorrow},
+		namedPart{"overflow", a.overflow})
+}
+
+func (a *AdderSubtractor) parts() []namedPart {
+	parts := a.switchParts("bits")
+
+	if a.subSwitch != nil {
+		parts = append(parts, namedPart{"subSwitch", a.subSwitch})
+	}
+
+	return append(parts,
+		namedPart{"comp", a.comp},
+		namedPart{"adder", a.adder},
+		namedPart{"carryOrBorrow", a.carryOrBorrow},
+		namedPart{"overflow", a.overflow})
+}
+
+func (a *AdderSubtractor) outputs() []namedPart {
+	return append(prefixParts("adder", a.adder.outputs()),
+		namedPart{"carryOrBorrow", a.carryOrBorrow},
+		namedPart{"overflow", a.overflow})
+}
+
+// String is the raw answer of the inner adder, including its carry out when it's 1, see Result, CarryOrBorrow and Overflow to read it properly
+func (a *AdderSubtractor) String() string {
+	return a.adder.String()
+}
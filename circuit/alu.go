@@ -0,0 +1,458 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type ALUOp int
+
+const (
+	ADD ALUOp = iota // A + B
+	ADC              // A + B + carry in
+	SUB              // A - B
+	SBB              // A - B - carry in (the carry in being a borrow)
+	AND              // A AND B, bit by bit
+	OR               // A OR B, bit by bit
+	XOR              // A XOR B, bit by bit
+	NOT              // NOT A, bit by bit (B is ignored)
+	CMP              // A - B, only for the flags (a CPU would throw the result away)
+	INC              // A + 1 (B is ignored)
+	DEC              // A - 1 (B is ignored)
+)
+
+// aluOpWidth is how many select lines the opcode takes (opcodes 11 through 15 select nothing, so the result is all 0s)
+const aluOpWidth = 4
+
+func (o ALUOp) String() string {
+	names := []string{"ADD", "ADC", "SUB", "SBB", "AND", "OR", "XOR", "NOT", "CMP", "INC", "DEC"}
+
+	if o < 0 || int(o) >= len(names) {
+		return fmt.Sprintf("ALUOp(%d)", int(o))
+	}
+
+	return names[o]
+}
+
+// Arithmetic Logic Unit
+// A single adder does all the arithmetic, with its second input and carry in bent to suit the operation (the opcode is decoded into a few control lines)
+//
+// op    second input   carry in
+// ADD   B              0
+// ADC   B              carry in
+// SUB   NOT B          1
+// SBB   NOT B          NOT carry in
+// CMP   NOT B          1
+// INC   0              1
+// DEC   1s             0
+//
+// The logic operations are done off to the side, and the decoded opcode picks which answer makes it to the result.  The flags are then read off the result
+// Zero      every result bit is 0
+// Carry     the answer (read unsigned) didn't fit, a carry out when adding, a borrow when subtracting (always 0 for logic)
+// Sign      the leftmost result bit
+// Parity    the result has an even number of 1s
+// Overflow  the answer (read signed) didn't fit (always 0 for logic)
+
+type ALU struct {
+	switchInputs
+	opSwitches    *Bus
+	carrySwitch   *Switch
+	op            *Bus
+	carryIn       emitter
+	decoder       *decoder
+	arithmetic    *orGateN // any of the operations done by the adder
+	useB          *orGateN // the adder's second input is B (otherwise 0)
+	invertB       *orGateN // the adder's second input is inverted, i.e. subtracting
+	useCarry      *orGateN // the carry in reaches the adder
+	carryOne      *orGateN // the adder's carry in is inverted (a 1 when the carry in doesn't reach it)
+	carryGate     *andGate
+	adderCarryIn  *xorGate
+	adder         *Adder
+	slices        []*aluSlice
+	zero          emitter
+	carry         *andGate
+	carryOrBorrow *xorGate
+	parity        emitter
+	overflow      *andGate
+	overflowXOR   *xorGate
+}
+
+// aluSlice is everything for a single bit of the ALU besides its full adder
+type aluSlice struct {
+	bGate  *andGate // B, or 0 when the second input isn't used
+	bInv   *xorGate // then inverted when subtracting
+	and    *andGate
+	or     *orGate
+	xor    *xorGate
+	not    *inverter
+	picks  []*andGate // the sum, AND, OR, XOR and NOT answers, each gated by its decoded opcode
+	result *orGateN
+}
+
+// NewALU builds an ALU of any width (at least 1 bit) from two strings of that many 0s and 1s, along with switches for the opcode and carry in that can be
+// set later via SetOp and SetCarryIn
+func NewALU(width int, bits1, bits2 string, op ALUOp, carryIn bool) (*ALU, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("ALU width must be at least 1, but got ", width))
+	}
+
+	if err := validateALUOp(op); err != nil {
+		return nil, err
+	}
+
+	bits1, bits2, err := parseOperandPair(width, bits1, bits2)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBits(width, bits1, bits2); err != nil {
+		return nil, err
+	}
+
+	a := &ALU{}
+
+	a.bits1Switches = newSwitchBusFromBits(bits1)
+	a.bits2Switches = newSwitchBusFromBits(bits2)
+	a.opSwitches, _ = NewSwitchBus(aluOpWidth, uint64(op))
+	a.carrySwitch = NewSwitch(carryIn)
+
+	if err := a.build(a.bits1Switches, a.bits2Switches, a.opSwitches, a.carrySwitch); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// NewALUFromBuses builds an ALU as wide as the two Buses, wired directly to them, to a 4-bit Bus holding the opcode and to whatever drives the carry in,
+// so it follows any change on any of them
+func NewALUFromBuses(bus1, bus2, op *Bus, carryIn emitter) (*ALU, error) {
+	if bus1.Width() != bus2.Width() {
+		return nil, errors.New(fmt.Sprintf("Inputs are not the same width: %d and %d bits", bus1.Width(), bus2.Width()))
+	}
+
+	if bus1.Width() < 1 {
+		return nil, errors.New(fmt.Sprint("ALU width must be at least 1, but got ", bus1.Width()))
+	}
+
+	if op.Width() != aluOpWidth {
+		return nil, errors.New(fmt.Sprintf("Opcode must be %d bits, but got %d", aluOpWidth, op.Width()))
+	}
+
+	a := &ALU{}
+
+	if err := a.build(bus1, bus2, op, carryIn); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *ALU) build(bus1, bus2, op *Bus, carryIn emitter) error {
+	var err error
+
+	a.op = op
+	a.carryIn = carryIn
+
+	if a.decoder, err = newDecoder(op); err != nil {
+		return err
+	}
+
+	lines := func(ops ...ALUOp) []emitter {
+		var pins []emitter
+		for _, o := range ops {
+			pins = append(pins, a.decoder.lines[o])
+		}
+		return pins
+	}
+
+	if a.arithmetic, err = newORGateN(lines(ADD, ADC, SUB, SBB, CMP, INC, DEC)...); err != nil {
+		return err
+	}
+	if a.useB, err = newORGateN(lines(ADD, ADC, SUB, SBB, CMP)...); err != nil {
+		return err
+	}
+	if a.invertB, err = newORGateN(lines(SUB, SBB, CMP, DEC)...); err != nil {
+		return err
+	}
+	if a.useCarry, err = newORGateN(lines(ADC, SBB)...); err != nil {
+		return err
+	}
+	if a.carryOne, err = newORGateN(lines(SUB, SBB, CMP, INC)...); err != nil {
+		return err
+	}
+
+	a.carryGate = newANDGate(carryIn, a.useCarry)
+	a.adderCarryIn = newXORGate(a.carryGate, a.carryOne)
+
+	addend := &Bus{}
+	for i := range bus1.wires {
+		s := &aluSlice{}
+		s.bGate = newANDGate(bus2.wires[i], a.useB)
+		s.bInv = newXORGate(s.bGate, a.invertB)
+		a.slices = append(a.slices, s)
+
+		addend.wires = append(addend.wires, s.bInv)
+	}
+
+	a.adder = newNamedAdderFromBuses("bits", bus1, addend, a.adderCarryIn)
+
+	result := &Bus{}
+	for i, s := range a.slices {
+		s.and = newANDGate(bus1.wires[i], bus2.wires[i])
+		s.or = newORGate(bus1.wires[i], bus2.wires[i])
+		s.xor = newXORGate(bus1.wires[i], bus2.wires[i])
+		s.not = newInverter(bus1.wires[i])
+
+		s.picks = []*andGate{
+			newANDGate(a.adder.fullAdders[i].sum, a.arithmetic),
+			newANDGate(s.and, a.decoder.lines[AND]),
+			newANDGate(s.or, a.decoder.lines[OR]),
+			newANDGate(s.xor, a.decoder.lines[XOR]),
+			newANDGate(s.not, a.decoder.lines[NOT]),
+		}
+
+		pins := make([]emitter, len(s.picks))
+		for j, p := range s.picks {
+			pins[j] = p
+		}
+		if s.result, err = newORGateN(pins...); err != nil {
+			return err
+		}
+
+		result.wires = append(result.wires, s.result)
+	}
+
+	if a.Width() == 1 {
+		a.zero = newInverter(result.wires[0])
+		a.parity = newInverter(result.wires[0])
+	} else {
+		if a.zero, err = newNORGateN(result.wires...); err != nil {
+			return err
+		}
+		if a.parity, err = newXNORGateN(result.wires...); err != nil {
+			return err
+		}
+	}
+
+	a.carryOrBorrow = newXORGate(a.adder.carryOut, a.invertB)
+	a.carry = newANDGate(a.carryOrBorrow, a.arithmetic)
+
+	var msbCarryIn emitter = a.adderCarryIn // the carry into the leftmost bit, which for a 1-bit ALU is the adder's own carry in
+	if a.Width() > 1 {
+		msbCarryIn = a.adder.fullAdders[1].carry
+	}
+	a.overflowXOR = newXORGate(msbCarryIn, a.adder.carryOut)
+	a.overflow = newANDGate(a.overflowXOR, a.arithmetic)
+
+	return nil
+}
+
+// Width is how many bits the ALU works on
+func (a *ALU) Width() int {
+	return len(a.slices)
+}
+
+// Op is the operation the opcode lines currently select
+func (a *ALU) Op() ALUOp {
+	return ALUOp(a.op.Uint())
+}
+
+// SetOp flips the opcode switches to select a new operation
+func (a *ALU) SetOp(op ALUOp) error {
+	if a.opSwitches == nil {
+		return errors.New("ALU opcode is driven by a Bus, not switches, so it cannot be set")
+	}
+
+	if err := validateALUOp(op); err != nil {
+		return err
+	}
+
+	return a.opSwitches.SetUint(uint64(op))
+}
+
+func validateALUOp(op ALUOp) error {
+	if op < ADD || op > DEC {
+		return errors.New(fmt.Sprint("Unknown ALU operation: ", op))
+	}
+
+	return nil
+}
+
+// SetCarryIn flips the carry in switch (only ADC and SBB look at it)
+func (a *ALU) SetCarryIn(carryIn bool) error {
+	if a.carrySwitch == nil {
+		return errors.New("ALU carry in is driven from outside, not by a switch, so it cannot be set")
+	}
+
+	a.carrySwitch.Set(carryIn)
+
+	return nil
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing ALU settles on the new answer without being rebuilt
+func (a *ALU) UpdateInputs(bits1, bits2 string) error {
+	return a.updateSwitches("ALU", a.Width(), bits1, bits2)
+}
+
+// Result returns the answer as a Bus that can be wired into other circuits
+func (a *ALU) Result() *Bus {
+	b := &Bus{}
+
+	for _, s := range a.slices {
+		b.wires = append(b.wires, s.result)
+	}
+
+	return b
+}
+
+// Zero is true when every bit of the result is 0
+func (a *ALU) Zero() bool {
+	return a.zero.Emitting()
+}
+
+// Carry is true when the answer (read unsigned) didn't fit: a carry out when adding, or a borrow when subtracting
+func (a *ALU) Carry() bool {
+	return a.carry.Emitting()
+}
+
+// Sign is true when the leftmost bit of the result is 1, i.e. the result is negative when read signed
+func (a *ALU) Sign() bool {
+	return a.slices[0].result.Emitting()
+}
+
+// Parity is true when the result has an even number of 1s
+func (a *ALU) Parity() bool {
+	return a.parity.Emitting()
+}
+
+// Overflow is true when the answer (read signed) didn't fit, e.g. 127 + 1 or -128 - 1 in 8 bits
+func (a *ALU) Overflow() bool {
+	return a.overflow.Emitting()
+}
+
+// Flags lists the flags that are set (Z, C, S, P and O), with a - in place of any that aren't, e.g. "Z--P-" for a result of all 0s
+func (a *ALU) Flags() string {
+	var sb strings.Builder
+
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"Z", a.Zero()},
+		{"C", a.Carry()},
+		{"S", a.Sign()},
+		{"P", a.Parity()},
+		{"O", a.Overflow()},
+	} {
+		if f.set {
+			sb.WriteString(f.name)
+		} else {
+			sb.WriteString("-")
+		}
+	}
+
+	return sb.String()
+}
+
+func (s *aluSlice) ports() []namedPart {
+	return []namedPart{
+		{"bits1", portOf(s.and, "pin1")},
+		{"bits2", portOf(s.and, "pin2")},
+		{"addend", s.bInv},
+		{"result", s.result},
+	}
+}
+
+func (s *aluSlice) parts() []namedPart {
+	parts := []namedPart{
+		{"bGate", s.bGate},
+		{"bInv", s.bInv},
+		{"and", s.and},
+		{"or", s.or},
+		{"xor", s.xor},
+		{"not", s.not},
+	}
+
+	for i, p := range s.picks {
+		parts = append(parts, namedPart{fmt.Sprintf("picks[%d]", i), p})
+	}
+
+	return append(parts, namedPart{"result", s.result})
+}
+
+func (a *ALU) ports() []namedPart {
+	ports := []namedPart{{"carryIn", a.carryIn}}
+
+	for i, w := range a.op.wires {
+		ports = append(ports, namedPart{fmt.Sprintf("op[%d]", i), w})
+	}
+
+	for i, s := range a.slices {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("bits1[%d]", i), portOf(s, "bits1")},
+			namedPart{fmt.Sprintf("bits2[%d]", i), portOf(s, "bits2")},
+			namedPart{fmt.Sprintf("result[%d]", i), s.result})
+	}
+
+	return append(ports, a.flagParts()...)
+}
+
+func (a *ALU) parts() []namedPart {
+	parts := a.switchParts("bits")
+
+	if a.opSwitches != nil {
+		for i, w := range a.opSwitches.wires {
+			parts = append(parts, namedPart{fmt.Sprintf("opSwitches[%d]", i), w})
+		}
+		parts = append(parts, namedPart{"carrySwitch", a.carrySwitch})
+	}
+
+	parts = append(parts,
+		namedPart{"decoder", a.decoder},
+		namedPart{"arithmetic", a.arithmetic},
+		namedPart{"useB", a.useB},
+		namedPart{"invertB", a.invertB},
+		namedPart{"useCarry", a.useCarry},
+		namedPart{"carryOne", a.carryOne},
+		namedPart{"carryGate", a.carryGate},
+		namedPart{"adderCarryIn", a.adderCarryIn},
+		namedPart{"adder", a.adder})
+
+	for i, s := range a.slices {
+		parts = append(parts, namedPart{fmt.Sprintf("slices[%d]", i), s})
+	}
+
+	return append(parts,
+		namedPart{"zero", a.zero},
+		namedPart{"carryOrBorrow", a.carryOrBorrow},
+		namedPart{"carry", a.carry},
+		namedPart{"parity", a.parity},
+		namedPart{"overflowXOR", a.overflowXOR},
+		namedPart{"overflow", a.overflow})
+}
+
+func (a *ALU) outputs() []namedPart {
+	var parts []namedPart
+
+	for i, s := range a.slices {
+		parts = append(parts, namedPart{fmt.Sprintf("result[%d]", i), s.result})
+	}
+
+	return append(parts, a.flagParts()...)
+}
+
+func (a *ALU) flagParts() []namedPart {
+	return []namedPart{
+		{"zero", a.zero},
+		{"carry", a.carry},
+		{"sign", a.slices[0].result},
+		{"parity", a.parity},
+		{"overflow", a.overflow},
+	}
+}
+
+// String is the result bits
+func (a *ALU) String() string {
+	return a.Result().String()
+}
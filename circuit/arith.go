@@ -0,0 +1,70 @@
+package circuit
+
+// Integer arithmetic run through the real gate-level circuits, so Go programs can use the simulator without formatting bit strings or parsing String()
+// output (including its optional leading carry digit)
+
+// addUint wires two Switch Buses of the given width into an adder and reads back the sum and carry out
+func addUint(width int, a, b uint64, carryIn bool) (uint64, bool) {
+	aBus, _ := NewSwitchBus(width, a) // the callers' types guarantee the values fit
+	bBus, _ := NewSwitchBus(width, b)
+
+	adder, _ := NewAdderFromBuses(aBus, bBus, NewSwitch(carryIn))
+
+	return adder.Sum().Uint(), adder.CarryOut().Emitting()
+}
+
+// subUint wires two Switch Buses of the given width into a subtractor and reads back the difference and whether it had to borrow
+func subUint(width int, a, b uint64) (uint64, bool) {
+	aBus, _ := NewSwitchBus(width, a)
+	bBus, _ := NewSwitchBus(width, b)
+
+	s, _ := NewSubtractorFromBuses(aBus, bBus)
+
+	return s.Difference().Uint(), s.Borrow()
+}
+
+// AddUint8 adds two bytes (plus a carry in) through an 8-bit adder
+func AddUint8(a, b uint8, carryIn bool) (sum uint8, carryOut bool) {
+	s, c := addUint(8, uint64(a), uint64(b), carryIn)
+	return uint8(s), c
+}
+
+// AddUint16 adds two 16-bit values (plus a carry in) through a 16-bit adder
+func AddUint16(a, b uint16, carryIn bool) (sum uint16, carryOut bool) {
+	s, c := addUint(16, uint64(a), uint64(b), carryIn)
+	return uint16(s), c
+}
+
+// AddUint32 adds two 32-bit values (plus a carry in) through a 32-bit adder
+func AddUint32(a, b uint32, carryIn bool) (sum uint32, carryOut bool) {
+	s, c := addUint(32, uint64(a), uint64(b), carryIn)
+	return uint32(s), c
+}
+
+// AddUint64 adds two 64-bit values (plus a carry in) through a 64-bit adder
+func AddUint64(a, b uint64, carryIn bool) (sum uint64, carryOut bool) {
+	return addUint(64, a, b, carryIn)
+}
+
+// Sub8 subtracts b from a through an 8-bit adder, wrapping around just like Go does when b is bigger (which is also when it borrows)
+func Sub8(a, b uint8) (difference uint8, borrow bool) {
+	d, borrow := subUint(8, uint64(a), uint64(b))
+	return uint8(d), borrow
+}
+
+// Sub16 subtracts b from a through a 16-bit adder, wrapping around just like Go does when b is bigger (which is also when it borrows)
+func Sub16(a, b uint16) (difference uint16, borrow bool) {
+	d, borrow := subUint(16, uint64(a), uint64(b))
+	return uint16(d), borrow
+}
+
+// Sub32 subtracts b from a through a 32-bit adder, wrapping around just like Go does when b is bigger (which is also when it borrows)
+func Sub32(a, b uint32) (difference uint32, borrow bool) {
+	d, borrow := subUint(32, uint64(a), uint64(b))
+	return uint32(d), borrow
+}
+
+// Sub64 subtracts b from a through a 64-bit adder, wrapping around just like Go does when b is bigger (which is also when it borrows)
+func Sub64(a, b uint64) (difference uint64, borrow bool) {
+	return subUint(64, a, b)
+}
@@ -0,0 +1,579 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// overNine is on when a 4-bit digit (MSB-first) is more than 9, i.e. one of the six codes 1010 through 1111 that BCD doesn't use
+type overNine struct {
+	or  *orGate  // the 4s or 2s place
+	and *andGate // along with the 8s place
+}
+
+func newOverNine(digit []emitter) *overNine {
+	o := &overNine{}
+
+	o.or = newORGate(digit[1], digit[2])
+	o.and = newANDGate(digit[0], o.or)
+
+	return o
+}
+
+func (o *overNine) ports() []namedPart {
+	return []namedPart{
+		{"digit[0]", portOf(o.and, "pin1")},
+		{"digit[1]", portOf(o.or, "pin1")},
+		{"digit[2]", portOf(o.or, "pin2")},
+		{"out", o.and},
+	}
+}
+
+func (o *overNine) parts() []namedPart {
+	return []namedPart{
+		{"or", o.or},
+		{"and", o.and},
+	}
+}
+
+func (o *overNine) source() *pwrSource {
+	return o.and.source()
+}
+
+func (o *overNine) WireUp(ch func(Signal)) {
+	o.and.WireUp(ch)
+}
+
+func (o *overNine) Emitting() bool {
+	return o.and.Emitting()
+}
+
+func (o *overNine) Signal() Signal {
+	return o.and.Signal()
+}
+
+// BCD Digit Adder
+// A 4-bit binary adder sums two decimal digits (plus a carry in, so at most 19), then when that binary sum is more than 9 a second adder adds 6 to skip
+// over the six unused codes, which is also the decimal carry out
+//    0111   (7)
+// +  0101   (5)
+// =  1100   (12, but not a decimal digit)
+// +  0110
+// = 10010   (carry 1, digit 2)
+
+type BCDDigitAdder struct {
+	digit1Switches *Bus // only when built from bit strings, otherwise the inputs belong to whatever drives them
+	digit2Switches *Bus
+	binary         *Adder
+	overNine       *overNine
+	carryOut       *orGate // the binary carry out (sums of 16 and up) or more than 9
+	correction     *Adder  // adds 0110 when carrying, its own carry out is thrown away
+}
+
+// NewBCDDigitAdder builds an adder for two 4-bit decimal digits (0000 through 1001)
+func NewBCDDigitAdder(digit1, digit2 string, carryIn emitter) (*BCDDigitAdder, error) {
+	if err := validateBCD(4, digit1, digit2); err != nil {
+		return nil, err
+	}
+
+	d := &BCDDigitAdder{}
+
+	d.digit1Switches = newSwitchBusFromBits(digit1)
+	d.digit2Switches = newSwitchBusFromBits(digit2)
+
+	d.build(d.digit1Switches, d.digit2Switches, carryIn)
+
+	return d, nil
+}
+
+// NewBCDDigitAdderFromBuses builds a digit adder wired directly to two 4-bit Buses (e.g. the Sum of another digit adder), so it follows any change on them
+func NewBCDDigitAdderFromBuses(bus1, bus2 *Bus, carryIn emitter) (*BCDDigitAdder, error) {
+	if bus1.Width() != 4 {
+		return nil, errors.New(fmt.Sprint("First input not a 4-bit Bus, width: ", bus1.Width()))
+	}
+
+	if bus2.Width() != 4 {
+		return nil, errors.New(fmt.Sprint("Second input not a 4-bit Bus, width: ", bus2.Width()))
+	}
+
+	d := &BCDDigitAdder{}
+
+	d.build(bus1, bus2, carryIn)
+
+	return d, nil
+}
+
+func (d *BCDDigitAdder) build(bus1, bus2 *Bus, carryIn emitter) {
+	d.binary = newNamedAdderFromBuses("digit", bus1, bus2, carryIn)
+	d.overNine = newOverNine(d.binary.Sum().wires)
+	d.carryOut = newORGate(d.binary.carryOut, d.overNine)
+	d.correction = newNamedAdderFromBuses("bits", d.binary.Sum(), NewBus(nil, d.carryOut, d.carryOut, nil), nil)
+}
+
+// Width is how many bits the digit adder sums (always 4)
+func (d *BCDDigitAdder) Width() int {
+	return 4
+}
+
+// UpdateInputs flips the input switches to match the new digits, so the existing digit adder settles on the new answer without being rebuilt
+func (d *BCDDigitAdder) UpdateInputs(digit1, digit2 string) error {
+	if d.digit1Switches == nil {
+		return errors.New("BCD digit adder inputs are driven by Buses, not switches, so they cannot be updated")
+	}
+
+	if err := validateBCD(4, digit1, digit2); err != nil {
+		return err
+	}
+
+	Simultaneously(func() {
+		d.digit1Switches.setBits(digit1)
+		d.digit2Switches.setBits(digit2)
+	})
+
+	return nil
+}
+
+// Sum returns the corrected sum digit as a Bus that can be wired into other circuits
+func (d *BCDDigitAdder) Sum() *Bus {
+	return d.correction.Sum()
+}
+
+// CarryOut returns the decimal carry out (the sum was 10 or more) so it can be wired into the next digit adder to the left
+func (d *BCDDigitAdder) CarryOut() emitter {
+	return d.carryOut
+}
+
+func (d *BCDDigitAdder) ports() []namedPart {
+	var ports []namedPart
+
+	for i := 0; i < 4; i++ {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("digit1[%d]", i), portOf(d.binary, fmt.Sprintf("digit1[%d]", i))},
+			namedPart{fmt.Sprintf("digit2[%d]", i), portOf(d.binary, fmt.Sprintf("digit2[%d]", i))},
+			namedPart{fmt.Sprintf("sum[%d]", i), d.correction.fullAdders[i].sum})
+	}
+
+	return append(ports,
+		namedPart{"carryIn", portOf(d.binary, "carryIn")},
+		namedPart{"carryOut", d.carryOut})
+}
+
+func (d *BCDDigitAdder) parts() []namedPart {
+	var parts []namedPart
+
+	if d.digit1Switches != nil {
+		for i := 0; i < 4; i++ {
+			parts = append(parts,
+				namedPart{fmt.Sprintf("digit1Switches[%d]", i), d.digit1Switches.wires[i]},
+				namedPart{fmt.Sprintf("digit2Switches[%d]", i), d.digit2Switches.wires[i]})
+		}
+	}
+
+	return append(parts,
+		namedPart{"binary", d.binary},
+		namedPart{"overNine", d.overNine},
+		namedPart{"carryOut", d.carryOut},
+		namedPart{"correction", d.correction})
+}
+
+func (d *BCDDigitAdder) outputs() []namedPart {
+	parts := []namedPart{{"carryOut", d.carryOut}}
+
+	for i, f := range d.correction.fullAdders {
+		parts = append(parts, namedPart{fmt.Sprintf("sum[%d]", i), f.sum})
+	}
+
+	return parts
+}
+
+func (d *BCDDigitAdder) String() string {
+	return sumString(d)
+}
+
+// BCD Adder
+// Packed BCD digit adders chained together (4 bits per decimal digit), each one's decimal carry out rippling into the carry in of its neighbor to the left
+//    0100 1001   (49)
+// +  0011 0101   (35)
+// =  1000 0100   (84)
+
+type BCDAdder struct {
+	bits1Switches *Bus // only when built from bit strings, otherwise the inputs belong to whatever drives them
+	bits2Switches *Bus
+	digitAdders   []*BCDDigitAdder
+}
+
+// NewBCDAdder builds an adder for any number of packed BCD digits from two strings of that many groups of 4 0s and 1s (each 0000 through 1001)
+func NewBCDAdder(bits1, bits2 string, carryIn emitter) (*BCDAdder, error) {
+	if err := validateBCD(len(bits1), bits1, bits2); err != nil {
+		return nil, err
+	}
+
+	a := &BCDAdder{}
+
+	a.bits1Switches = newSwitchBusFromBits(bits1)
+	a.bits2Switches = newSwitchBusFromBits(bits2)
+
+	if err := a.build(a.bits1Switches, a.bits2Switches, carryIn); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// NewBCDAdderFromBuses builds an adder as wide as the two Buses (a multiple of 4 bits), wired directly to them, so it follows any change on them
+func NewBCDAdderFromBuses(bus1, bus2 *Bus, carryIn emitter) (*BCDAdder, error) {
+	if bus1.Width() != bus2.Width() {
+		return nil, errors.New(fmt.Sprintf("Inputs are not the same width: %d and %d bits", bus1.Width(), bus2.Width()))
+	}
+
+	if bus1.Width() == 0 || bus1.Width()%4 != 0 {
+		return nil, errors.New(fmt.Sprint("BCD inputs must be a whole number of 4-bit digits, but got width ", bus1.Width()))
+	}
+
+	a := &BCDAdder{}
+
+	if err := a.build(bus1, bus2, carryIn); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *BCDAdder) build(bus1, bus2 *Bus, carryIn emitter) error {
+	digits := bus1.Width() / 4
+	a.digitAdders = make([]*BCDDigitAdder, digits)
+
+	for i := digits - 1; i >= 0; i-- {
+		if i < digits-1 {
+			carryIn = a.digitAdders[i+1].carryOut // carry-in is the neighboring digit adders carry-out
+		}
+
+		d, err := NewBCDDigitAdderFromBuses(bus1.Slice(i*4, i*4+4), bus2.Slice(i*4, i*4+4), carryIn)
+		if err != nil {
+			return err
+		}
+
+		a.digitAdders[i] = d
+	}
+
+	return nil
+}
+
+// Width is how many bits the adder sums (4 per digit, not counting the carry)
+func (a *BCDAdder) Width() int {
+	return len(a.digitAdders) * 4
+}
+
+// UpdateInputs flips the input switches to match the new digits, so the existing adder settles on the new answer without being rebuilt
+func (a *BCDAdder) UpdateInputs(bits1, bits2 string) error {
+	if a.bits1Switches == nil {
+		return errors.New("BCD adder inputs are driven by Buses, not switches, so they cannot be updated")
+	}
+
+	if err := validateBCD(a.Width(), bits1, bits2); err != nil {
+		return err
+	}
+
+	Simultaneously(func() {
+		a.bits1Switches.setBits(bits1)
+		a.bits2Switches.setBits(bits2)
+	})
+
+	return nil
+}
+
+// Sum returns the packed BCD sum digits as a Bus that can be wired into other circuits
+func (a *BCDAdder) Sum() *Bus {
+	b := &Bus{}
+
+	for _, d := range a.digitAdders {
+		b = b.Concat(d.Sum())
+	}
+
+	return b
+}
+
+// CarryOut returns the decimal carry out of the leftmost digit so it can be wired into other circuits
+func (a *BCDAdder) CarryOut() emitter {
+	return a.digitAdders[0].carryOut
+}
+
+// Decimal is the answer in plain decimal digits, e.g. "84" or "132", with a carry out as a leading 1
+func (a *BCDAdder) Decimal() string {
+	answer, err := BCDToDecimal(a.Sum().String())
+	if err != nil {
+		return "?"
+	}
+
+	if a.CarryOut().Signal() != Low {
+		answer = a.CarryOut().Signal().String() + answer
+	}
+
+	return answer
+}
+
+func (a *BCDAdder) ports() []namedPart {
+	var ports []namedPart
+
+	for i, d := range a.digitAdders {
+		for j := 0; j < 4; j++ {
+			ports = append(ports,
+				namedPart{fmt.Sprintf("bits1[%d]", i*4+j), portOf(d, fmt.Sprintf("digit1[%d]", j))},
+				namedPart{fmt.Sprintf("bits2[%d]", i*4+j), portOf(d, fmt.Sprintf("digit2[%d]", j))},
+				namedPart{fmt.Sprintf("sum[%d]", i*4+j), portOf(d, fmt.Sprintf("sum[%d]", j))})
+		}
+	}
+
+	return append(ports,
+		namedPart{"carryIn", portOf(a.digitAdders[len(a.digitAdders)-1], "carryIn")},
+		namedPart{"carryOut", a.CarryOut()})
+}
+
+func (a *BCDAdder) parts() []namedPart {
+	var parts []namedPart
+
+	if a.bits1Switches != nil {
+		for i := range a.bits1Switches.wires {
+			parts = append(parts,
+				namedPart{fmt.Sprintf("bits1Switches[%d]", i), a.bits1Switches.wires[i]},
+				namedPart{fmt.Sprintf("bits2Switches[%d]", i), a.bits2Switches.wires[i]})
+		}
+	}
+
+	for i, d := range a.digitAdders {
+		parts = append(parts, namedPart{fmt.Sprintf("digitAdders[%d]", i), d})
+	}
+
+	return parts
+}
+
+func (a *BCDAdder) outputs() []namedPart {
+	var parts []namedPart
+
+	for i, d := range a.digitAdders {
+		parts = append(parts, prefixParts(fmt.Sprintf("digitAdders[%d]", i), d.outputs())...)
+	}
+
+	return parts
+}
+
+func (a *BCDAdder) String() string {
+	return sumString(a)
+}
+
+// Decimal Adjust
+// Fixes up the binary sum of two packed BCD bytes into their BCD sum after the fact (like the 8080's DAA instruction), given the carry out of that sum
+// and its auxiliary carry (the carry out of the low 4 bits).  First 6 is added when the low digit is more than 9 or there was an auxiliary carry, then
+// 6 is added to the high digit when it's (now) more than 9 or there was a carry, which also sets the carry
+//    0100 1001   (49)
+// +  0011 0101   (35)
+// =  0111 1110   aux carry 0, carry 0
+//    1000 0100   adjusted (84)
+
+type DecimalAdjuster struct {
+	bitsSwitches *Bus // only when built from bit strings, otherwise the inputs belong to whatever drives them
+	carrySwitch  *Switch
+	auxSwitch    *Switch
+	lowOverNine  *overNine
+	lowFix       *orGate
+	low          *Adder // adds 0000 0110 when fixing the low digit
+	highOverNine *overNine
+	highCarries  *orGateN // the carry in, a carry out of fixing the low digit, or the high digit more than 9
+	high         *Adder   // adds 0110 0000 when fixing the high digit
+}
+
+// NewDecimalAdjuster builds a decimal adjuster for a string of 8 0s and 1s, along with its carry and auxiliary carry
+func NewDecimalAdjuster(bits string, carry, auxCarry bool) (*DecimalAdjuster, error) {
+	if err := validateBits(8, bits, bits); err != nil {
+		return nil, err
+	}
+
+	d := &DecimalAdjuster{}
+
+	d.bitsSwitches = newSwitchBusFromBits(bits)
+	d.carrySwitch = NewSwitch(carry)
+	d.auxSwitch = NewSwitch(auxCarry)
+
+	d.build(d.bitsSwitches, d.carrySwitch, d.auxSwitch)
+
+	return d, nil
+}
+
+// NewDecimalAdjusterFromBuses builds a decimal adjuster wired directly to an 8-bit Bus (e.g. the Sum of an EightBitAdder) and to whatever drives its carry
+// and auxiliary carry, so it follows any change on them
+func NewDecimalAdjusterFromBuses(bus *Bus, carry, auxCarry emitter) (*DecimalAdjuster, error) {
+	if bus.Width() != 8 {
+		return nil, errors.New(fmt.Sprint("Input not an 8-bit Bus, width: ", bus.Width()))
+	}
+
+	d := &DecimalAdjuster{}
+
+	d.build(bus, carry, auxCarry)
+
+	return d, nil
+}
+
+func (d *DecimalAdjuster) build(bus *Bus, carry, auxCarry emitter) {
+	d.lowOverNine = newOverNine(bus.wires[4:])
+	d.lowFix = newORGate(d.lowOverNine, auxCarry)
+	d.low = newNamedAdderFromBuses("bits", bus, NewBus(nil, nil, nil, nil, nil, d.lowFix, d.lowFix, nil), nil)
+
+	d.highOverNine = newOverNine(d.low.Sum().wires[:4])
+	d.highCarries, _ = newORGateN(carry, d.low.carryOut, d.highOverNine)
+	d.high = newNamedAdderFromBuses("bits", d.low.Sum(), NewBus(nil, d.highCarries, d.highCarries, nil, nil, nil, nil, nil), nil)
+}
+
+// UpdateInputs flips the input switches to match the new bits and carries, so the existing decimal adjuster settles on the new answer without being rebuilt
+func (d *DecimalAdjuster) UpdateInputs(bits string, carry, auxCarry bool) error {
+	if d.bitsSwitches == nil {
+		return errors.New("Decimal adjuster inputs are driven from outside, not by switches, so they cannot be updated")
+	}
+
+	if err := validateBits(8, bits, bits); err != nil {
+		return err
+	}
+
+	Simultaneously(func() {
+		d.bitsSwitches.setBits(bits)
+		d.carrySwitch.Set(carry)
+		d.auxSwitch.Set(auxCarry)
+	})
+
+	return nil
+}
+
+// Result returns the adjusted packed BCD byte as a Bus that can be wired into other circuits
+func (d *DecimalAdjuster) Result() *Bus {
+	return d.high.Sum()
+}
+
+// CarryOut returns the decimal carry out (the BCD sum was 100 or more) so it can be wired into other circuits
+func (d *DecimalAdjuster) CarryOut() emitter {
+	return d.highCarries
+}
+
+func (d *DecimalAdjuster) ports() []namedPart {
+	var ports []namedPart
+
+	for i := 0; i < 8; i++ {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("bits[%d]", i), portOf(d.low, fmt.Sprintf("bits1[%d]", i))},
+			namedPart{fmt.Sprintf("result[%d]", i), d.high.fullAdders[i].sum})
+	}
+
+	return append(ports,
+		namedPart{"carry", portOf(d.highCarries, "pin1")},
+		namedPart{"auxCarry", portOf(d.lowFix, "pin2")},
+		namedPart{"carryOut", d.highCarries})
+}
+
+func (d *DecimalAdjuster) parts() []namedPart {
+	var parts []namedPart
+
+	if d.bitsSwitches != nil {
+		for i, w := range d.bitsSwitches.wires {
+			parts = append(parts, namedPart{fmt.Sprintf("bitsSwitches[%d]", i), w})
+		}
+		parts = append(parts,
+			namedPart{"carrySwitch", d.carrySwitch},
+			namedPart{"auxSwitch", d.auxSwitch})
+	}
+
+	return append(parts,
+		namedPart{"lowOverNine", d.lowOverNine},
+		namedPart{"lowFix", d.lowFix},
+		namedPart{"low", d.low},
+		namedPart{"highOverNine", d.highOverNine},
+		namedPart{"highCarries", d.highCarries},
+		namedPart{"high", d.high})
+}
+
+func (d *DecimalAdjuster) outputs() []namedPart {
+	parts := []namedPart{{"carryOut", d.highCarries}}
+
+	for i, f := range d.high.fullAdders {
+		parts = append(parts, namedPart{fmt.Sprintf("result[%d]", i), f.sum})
+	}
+
+	return parts
+}
+
+// String is the adjusted byte, with the carry only shown when it isn't 0
+func (d *DecimalAdjuster) String() string {
+	answer := ""
+
+	if d.highCarries.Signal() != Low {
+		answer += d.highCarries.Signal().String()
+	}
+
+	return answer + d.Result().String()
+}
+
+// validateBCD makes sure both inputs of a BCD circuit are strings of exactly width 0s and 1s, in whole 4-bit digits no bigger than 1001
+func validateBCD(width int, bits1, bits2 string) error {
+	if width == 0 || width%4 != 0 {
+		return errors.New(fmt.Sprint("BCD inputs must be a whole number of 4-bit digits, but got width ", width))
+	}
+
+	if err := validateBits(width, bits1, bits2); err != nil {
+		return err
+	}
+
+	for _, bits := range []string{bits1, bits2} {
+		if _, err := BCDToDecimal(bits); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecimalToBCD turns a string of decimal digits into packed BCD, 4 bits per digit and padded with 0s on the left to the given number of digits
+func DecimalToBCD(decimal string, digits int) (string, error) {
+	match, err := regexp.MatchString("^[0-9]+$", decimal)
+	if err != nil {
+		return "", err
+	}
+	if !match {
+		return "", errors.New(fmt.Sprint("Input not in decimal format: " + decimal))
+	}
+
+	if len(decimal) > digits {
+		return "", errors.New(fmt.Sprintf("Input %s does not fit in %d decimal digits", decimal, digits))
+	}
+
+	var sb strings.Builder
+
+	for _, r := range strings.Repeat("0", digits-len(decimal)) + decimal {
+		sb.WriteString(fmt.Sprintf("%04b", r-'0'))
+	}
+
+	return sb.String(), nil
+}
+
+// BCDToDecimal turns packed BCD (4 bits per digit) back into a string of decimal digits
+func BCDToDecimal(bits string) (string, error) {
+	match, err := regexp.MatchString("^([01]{4})+$", bits)
+	if err != nil {
+		return "", err
+	}
+	if !match {
+		return "", errors.New(fmt.Sprint("Input not in packed BCD format: " + bits))
+	}
+
+	var sb strings.Builder
+
+	for i := 0; i < len(bits); i += 4 {
+		digit, _ := strconv.ParseUint(bits[i:i+4], 2, 4)
+		if digit > 9 {
+			return "", errors.New(fmt.Sprintf("Input not in packed BCD format: %s (%s is not a decimal digit)", bits, bits[i:i+4]))
+		}
+
+		sb.WriteString(strconv.Itoa(int(digit)))
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,152 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Bus is a bundle of wires treated as one binary number.  Wires are ordered most significant bit first, the same way the bit strings given to the adders read
+type Bus struct {
+	wires []emitter
+}
+
+func NewBus(wires ...emitter) *Bus {
+	b := &Bus{}
+
+	for _, w := range wires {
+		b.wires = append(b.wires, w)
+	}
+
+	return b
+}
+
+// NewSwitchBus makes a Bus of Switches (so it can be changed later via SetUint) initially set to the given value
+func NewSwitchBus(width int, value uint64) (*Bus, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Bus width must be at least 1, but got ", width))
+	}
+
+	b := &Bus{}
+
+	for i := 0; i < width; i++ {
+		b.wires = append(b.wires, NewSwitch(false))
+	}
+
+	if err := b.SetUint(value); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// newSwitchBusFromBits makes a Bus of Switches set from a string of 0s and 1s (already validated by the caller)
+func newSwitchBusFromBits(bits string) *Bus {
+	b := &Bus{}
+
+	for _, c := range bits {
+		b.wires = append(b.wires, NewSwitch(c == '1'))
+	}
+
+	return b
+}
+
+func (b *Bus) Width() int {
+	return len(b.wires)
+}
+
+// Bits returns the signal on each wire, most significant first
+func (b *Bus) Bits() []Signal {
+	bits := make([]Signal, len(b.wires))
+
+	for i, w := range b.wires {
+		bits[i] = signalOf(w)
+	}
+
+	return bits
+}
+
+// Uint reads the Bus as an unsigned number (any wire not emitting counts as 0, and only the lowest 64 bits fit)
+func (b *Bus) Uint() uint64 {
+	var v uint64
+
+	for _, w := range b.wires {
+		v <<= 1
+		if w != nil && w.Emitting() {
+			v |= 1
+		}
+	}
+
+	return v
+}
+
+// SetUint flips the Switches of the Bus to hold the value.  Every wire must be a Switch and the value must fit in the width of the Bus
+func (b *Bus) SetUint(value uint64) error {
+	if len(b.wires) < 64 && value>>uint(len(b.wires)) != 0 {
+		return errors.New(fmt.Sprintf("Value %d does not fit in a %d-bit Bus", value, len(b.wires)))
+	}
+
+	switches := make([]*Switch, len(b.wires))
+	for i, w := range b.wires {
+		s, ok := w.(*Switch)
+		if !ok {
+			return errors.New(fmt.Sprintf("Bus wire %d is not a Switch, so it cannot be set", i))
+		}
+		switches[i] = s
+	}
+
+	Simultaneously(func() {
+		for i := len(switches) - 1; i >= 0; i-- {
+			switches[i].Set(value&1 == 1)
+			value >>= 1
+		}
+	})
+
+	return nil
+}
+
+// setBits flips the Switches of the Bus to match a string of 0s and 1s (already validated by the caller to be the width of the Bus)
+func (b *Bus) setBits(bits string) error {
+	switches := make([]*Switch, len(b.wires))
+	for i, w := range b.wires {
+		s, ok := w.(*Switch)
+		if !ok {
+			return errors.New(fmt.Sprintf("Bus wire %d is not a Switch, so it cannot be set", i))
+		}
+		switches[i] = s
+	}
+
+	// the whole Bus changes at once, just like flipping a bank of switches with one hand
+	Simultaneously(func() {
+		for i, s := range switches {
+			s.Set(bits[i] == '1')
+		}
+	})
+
+	return nil
+}
+
+// Slice returns a Bus of the wires from index "from" up to (not including) "to", sharing the same wires, just like slicing the bit strings
+func (b *Bus) Slice(from, to int) *Bus {
+	return NewBus(b.wires[from:to]...)
+}
+
+// Concat returns a Bus of these wires followed by the wires of the other Buses (so this Bus ends up as the most significant bits)
+func (b *Bus) Concat(others ...*Bus) *Bus {
+	c := NewBus(b.wires...)
+
+	for _, o := range others {
+		c.wires = append(c.wires, o.wires...)
+	}
+
+	return c
+}
+
+func (b *Bus) String() string {
+	s := ""
+
+	for _, bit := range b.Bits() {
+		s += bit.String()
+	}
+
+	return s
+}
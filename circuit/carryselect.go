@@ -0,0 +1,224 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Carry-Select Adder
+// The rightmost block of carrySelectBlock bits is a plain ripple carry adder.  Every block to its left is built twice, once assuming no carry comes in and
+// once assuming one does, so both answers are ready (in parallel) by the time the real carry arrives and all that's left is to select the right one
+
+const carrySelectBlock = 4
+
+type CarrySelectAdder struct {
+	switchInputs
+	bits1      *Bus
+	bits2      *Bus
+	blocks     []*carrySelect // leftmost block first, just like the bits
+	rightAdder *Adder
+	carryIn    emitter
+	carryOut   emitter
+}
+
+// carrySelect is one block of a carry-select adder, with an adder for each possible carry in and a multiplexer picking between their answers
+type carrySelect struct {
+	noCarryAdder *Adder
+	carryAdder   *Adder
+	carryInv     *inverter
+	noCarrySums  []*andGate // noCarryAdder's sum, only when no carry comes in
+	carrySums    []*andGate // carryAdder's sum, only when a carry comes in
+	sums         []*orGate
+	carryAnd     *andGate
+	carryOut     *orGate
+}
+
+// NewCarrySelectAdder builds a carry-select adder of any width (at least 1 bit) from two strings of that many 0s and 1s
+func NewCarrySelectAdder(width int, bits1, bits2 string, carryIn emitter) (*CarrySelectAdder, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Adder width must be at least 1, but got ", width))
+	}
+
+	bits1, bits2, err := parseOperandPair(width, bits1, bits2)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBits(width, bits1, bits2); err != nil {
+		return nil, err
+	}
+
+	a := &CarrySelectAdder{}
+
+	a.bits1Switches = newSwitchBusFromBits(bits1)
+	a.bits2Switches = newSwitchBusFromBits(bits2)
+
+	a.build(a.bits1Switches, a.bits2Switches, carryIn)
+
+	return a, nil
+}
+
+// NewCarrySelectAdderFromBuses builds a carry-select adder as wide as the two Buses, wired directly to them, so it follows any change on them
+func NewCarrySelectAdderFromBuses(bus1, bus2 *Bus, carryIn emitter) (*CarrySelectAdder, error) {
+	if bus1.Width() != bus2.Width() {
+		return nil, errors.New(fmt.Sprintf("Inputs are not the same width: %d and %d bits", bus1.Width(), bus2.Width()))
+	}
+
+	if bus1.Width() < 1 {
+		return nil, errors.New(fmt.Sprint("Adder width must be at least 1, but got ", bus1.Width()))
+	}
+
+	a := &CarrySelectAdder{}
+
+	a.build(bus1, bus2, carryIn)
+
+	return a, nil
+}
+
+func (a *CarrySelectAdder) build(bus1, bus2 *Bus, carryIn emitter) {
+	a.bits1 = bus1
+	a.bits2 = bus2
+	a.carryIn = carryIn
+
+	right := bus1.Width() - carrySelectBlock
+	if right < 0 {
+		right = 0
+	}
+
+	a.rightAdder = newNamedAdderFromBuses("bits", bus1.Slice(right, bus1.Width()), bus2.Slice(right, bus2.Width()), carryIn)
+
+	carry := a.rightAdder.carryOut
+	for to := right; to > 0; to -= carrySelectBlock {
+		from := to - carrySelectBlock
+		if from < 0 {
+			from = 0
+		}
+
+		b := newCarrySelect(bus1.Slice(from, to), bus2.Slice(from, to), carry)
+		a.blocks = append([]*carrySelect{b}, a.blocks...)
+		carry = b.carryOut
+	}
+
+	a.carryOut = carry
+}
+
+func newCarrySelect(bus1, bus2 *Bus, carryIn emitter) *carrySelect {
+	b := &carrySelect{}
+
+	b.noCarryAdder = newNamedAdderFromBuses("bits", bus1, bus2, nil)
+	b.carryAdder = newNamedAdderFromBuses("bits", bus1, bus2, &Battery{})
+	b.carryInv = newInverter(carryIn)
+
+	for i := range b.noCarryAdder.fullAdders {
+		noCarrySum := newANDGate(b.noCarryAdder.fullAdders[i].sum, b.carryInv)
+		carrySum := newANDGate(b.carryAdder.fullAdders[i].sum, carryIn)
+
+		b.noCarrySums = append(b.noCarrySums, noCarrySum)
+		b.carrySums = append(b.carrySums, carrySum)
+		b.sums = append(b.sums, newORGate(noCarrySum, carrySum))
+	}
+
+	// a carry in can only ever add a carry out, never take one away, so no need to select between the two
+	b.carryAnd = newANDGate(b.carryAdder.carryOut, carryIn)
+	b.carryOut = newORGate(b.noCarryAdder.carryOut, b.carryAnd)
+
+	return b
+}
+
+func (b *carrySelect) ports() []namedPart {
+	ports := []namedPart{{"carryIn", portOf(b.carryInv, "in")}}
+
+	for i, s := range b.sums {
+		ports = append(ports, namedPart{fmt.Sprintf("sum[%d]", i), s})
+	}
+
+	return append(ports, namedPart{"carryOut", b.carryOut})
+}
+
+func (b *carrySelect) parts() []namedPart {
+	parts := []namedPart{
+		{"noCarryAdder", b.noCarryAdder},
+		{"carryAdder", b.carryAdder},
+		{"carryInv", b.carryInv},
+	}
+
+	for i := range b.sums {
+		parts = append(parts,
+			namedPart{fmt.Sprintf("noCarrySums[%d]", i), b.noCarrySums[i]},
+			namedPart{fmt.Sprintf("carrySums[%d]", i), b.carrySums[i]},
+			namedPart{fmt.Sprintf("sums[%d]", i), b.sums[i]})
+	}
+
+	return append(parts,
+		namedPart{"carryAnd", b.carryAnd},
+		namedPart{"carryOut", b.carryOut})
+}
+
+// Width is how many bits the adder sums (not counting the carry)
+func (a *CarrySelectAdder) Width() int {
+	return a.bits1.Width()
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing adder settles on the new answer without being rebuilt
+func (a *CarrySelectAdder) UpdateInputs(bits1, bits2 string) error {
+	return a.updateSwitches("Adder", a.Width(), bits1, bits2)
+}
+
+// Sum returns the sum bits as a Bus that can be wired into other circuits
+func (a *CarrySelectAdder) Sum() *Bus {
+	b := &Bus{}
+
+	for _, block := range a.blocks {
+		for _, s := range block.sums {
+			b.wires = append(b.wires, s)
+		}
+	}
+
+	return b.Concat(a.rightAdder.Sum())
+}
+
+// CarryOut returns the carry out of the leftmost block so it can be wired into other circuits
+func (a *CarrySelectAdder) CarryOut() emitter {
+	return a.carryOut
+}
+
+func (a *CarrySelectAdder) ports() []namedPart {
+	var ports []namedPart
+
+	for i, s := range a.Sum().wires {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("bits1[%d]", i), a.bits1.wires[i]},
+			namedPart{fmt.Sprintf("bits2[%d]", i), a.bits2.wires[i]},
+			namedPart{fmt.Sprintf("sum[%d]", i), s})
+	}
+
+	return append(ports,
+		namedPart{"carryIn", a.carryIn},
+		namedPart{"carryOut", a.carryOut})
+}
+
+func (a *CarrySelectAdder) parts() []namedPart {
+	parts := a.switchParts("bits")
+
+	for i, b := range a.blocks {
+		parts = append(parts, namedPart{fmt.Sprintf("blocks[%d]", i), b})
+	}
+
+	return append(parts, namedPart{"rightAdder", a.rightAdder})
+}
+
+func (a *CarrySelectAdder) outputs() []namedPart {
+	parts := []namedPart{{"carryOut", a.carryOut}}
+
+	for i, b := range a.blocks {
+		for j, s := range b.sums {
+			parts = append(parts, namedPart{fmt.Sprintf("blocks[%d].sums[%d]", i, j), s})
+		}
+	}
+
+	return append(parts, prefixParts("rightAdder", a.rightAdder.outputs()[1:])...) // the right adder's carryOut is internal
+}
+
+func (a *CarrySelectAdder) String() string {
+	return sumString(a)
+}
@@ -0,0 +1,110 @@
+package circuit
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Census is a count of everything a circuit is built from.  Batteries are only those powering relays and inverters (not any passed in as inputs)
+type Census struct {
+	Relays      int
+	Inverters   int
+	ANDContacts int
+	XContacts   int
+	Batteries   int
+	Switches    int
+	Gates       map[string]int // keyed by gate type (e.g. "AND", "NOR", "XNOR (inverter)", "OR (4-input)", "MUX (2:1)")
+}
+
+// TakeCensus walks the parts of a circuit (counting each part only once, no matter how many paths lead to it) and tallies up what it found
+func TakeCensus(circuit assembly) Census {
+	c := Census{Gates: map[string]int{}}
+	seen := map[interface{}]bool{}
+
+	var walk func(part interface{})
+	walk = func(part interface{}) {
+		if part == nil || seen[part] {
+			return
+		}
+		seen[part] = true
+
+		switch p := part.(type) {
+		case *relay:
+			c.Relays++
+			if _, ok := p.aIn.(*Battery); ok {
+				c.Batteries++
+			}
+		case *inverter:
+			c.Inverters++
+			c.Batteries++ // an inverter is always powered by its own battery
+		case *andContact:
+			c.ANDContacts++
+		case *xContact:
+			c.XContacts++
+		case *Switch:
+			c.Switches++
+		case *andGate:
+			c.Gates["AND"]++
+		case *orGate:
+			c.Gates["OR"]++
+		case *nandGate:
+			c.Gates["NAND"]++
+		case *norGate:
+			c.Gates["NOR"]++
+		case *xorGate:
+			c.Gates["XOR"]++
+		case *nandGate2:
+			c.Gates["NAND (inverter)"]++
+		case *norGate2:
+			c.Gates["NOR (inverter)"]++
+		case *xnorGate:
+			c.Gates["XNOR (inverter)"]++
+		case *andGateN:
+			c.Gates[fmt.Sprintf("AND (%d-input)", len(p.relays))]++
+		case *orGateN:
+			c.Gates[fmt.Sprintf("OR (%d-input)", len(p.relays))]++
+		case *nandGateN:
+			c.Gates[fmt.Sprintf("NAND (%d-input)", len(p.relays))]++
+		case *norGateN:
+			c.Gates[fmt.Sprintf("NOR (%d-input)", len(p.relays))]++
+		case *xorGateN:
+			c.Gates[fmt.Sprintf("XOR (%d-input)", len(p.xorGates)+1)]++
+		case *xnorGateN:
+			c.Gates[fmt.Sprintf("XNOR (%d-input)", len(p.xorGate.xorGates)+1)]++
+		case *mux2:
+			c.Gates["MUX (2:1)"]++
+		}
+
+		if a, ok := part.(assembly); ok {
+			for _, p := range a.parts() {
+				walk(p.part)
+			}
+		}
+	}
+
+	walk(circuit)
+
+	return c
+}
+
+// RelayEquivalents is the number of relays it would take to build the circuit, counting each inverter as the relay it really is
+func (c Census) RelayEquivalents() int {
+	return c.Relays + c.Inverters
+}
+
+func (c Census) String() string {
+	s := fmt.Sprintf("Relays: %d\nInverters: %d\nAND Contacts: %d\nX Contacts: %d\nBatteries: %d\nSwitches: %d\n",
+		c.Relays, c.Inverters, c.ANDContacts, c.XContacts, c.Batteries, c.Switches)
+
+	var gates []string
+	for g := range c.Gates {
+		gates = append(gates, g)
+	}
+	sort.Strings(gates)
+
+	for _, g := range gates {
+		s += fmt.Sprintf("%s Gates: %d\n", g, c.Gates[g])
+	}
+
+	return s
+}
@@ -1,13 +1,75 @@
 package circuit
 
+import "time"
+
 type emitter interface {
+	WireUp(ch func(Signal))
 	Emitting() bool
+	Signal() Signal
+}
+
+// pwrSource is the basis for anything that emits power.  It caches its own signal and pushes any change in that signal out to whatever has wired up to it
+type pwrSource struct {
+	signal      Signal
+	outChannels []func(Signal)
+	delay       time.Duration // only honored while a Simulation is running
+	stuck       bool          // a stuck-at fault has been injected
+	stuckSignal Signal
+	unfaulted   Signal // what the source would be transmitting if it weren't stuck
+}
+
+// WireUp allows a component to subscribe to signal changes of the source (and immediately tells the subscriber the current signal)
+func (p *pwrSource) WireUp(ch func(Signal)) {
+	p.outChannels = append(p.outChannels, ch)
+
+	ch(p.signal)
+}
+
+// Emitting returns true only if the cached signal of the source is High
+func (p *pwrSource) Emitting() bool {
+	return p.signal == High
+}
+
+// Signal returns the cached four-valued signal of the source
+func (p *pwrSource) Signal() Signal {
+	return p.signal
+}
+
+// transmit updates the signal of the source and, only if it changed, notifies all subscribers (then lets any feedback loops settle, if this is where the
+// change started)
+func (p *pwrSource) transmit(newSignal Signal) {
+	p.unfaulted = newSignal
+	if p.stuck {
+		newSignal = p.stuckSignal
+	}
+
+	if p.signal == newSignal {
+		return
+	}
+
+	p.signal = newSignal
+
+	Simultaneously(func() {
+		for _, ch := range p.outChannels {
+			ch(newSignal)
+		}
+	})
 }
 
 type Battery struct {
 }
 
+// WireUp on a Battery only needs to tell the subscriber it is powered since a Battery can never change state
+func (b *Battery) WireUp(ch func(Signal)) {
+	ch(High)
+}
+
 // Emitting on a Battery is always considered true (Battery never drains)
 func (b *Battery) Emitting() bool {
 	return true
 }
+
+// Signal on a Battery is always High
+func (b *Battery) Signal() Signal {
+	return High
+}
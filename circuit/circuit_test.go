@@ -2,6 +2,10 @@ package circuit
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +16,125 @@ import (
 // go test -race -cpu=1,2,4 (go max prox)
 // go test -v
 
+func TestPwrSource(t *testing.T) {
+	testCases := []struct {
+		signals     []Signal
+		wantPowered bool
+		wantSignal  Signal
+		wantPushes  int
+	}{
+		{[]Signal{}, false, Low, 1},
+		{[]Signal{Low}, false, Low, 1},
+		{[]Signal{High}, true, High, 2},
+		{[]Signal{High, High}, true, High, 2},
+		{[]Signal{High, Low}, false, Low, 3},
+		{[]Signal{High, Low, Low, High}, true, High, 4},
+		{[]Signal{Z}, false, Z, 2},
+		{[]Signal{High, X, X}, false, X, 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Transmitting %v", tc.signals), func(t *testing.T) {
+			p := &pwrSource{}
+
+			pushes := 0
+			p.WireUp(func(Signal) { pushes++ })
+
+			for _, s := range tc.signals {
+				p.transmit(s)
+			}
+
+			if got := p.Emitting(); got != tc.wantPowered {
+				t.Errorf("Wanted power %t, but got %t", tc.wantPowered, got)
+			}
+
+			if got := p.Signal(); got != tc.wantSignal {
+				t.Errorf("Wanted signal %s, but got %s", tc.wantSignal, got)
+			}
+
+			if pushes != tc.wantPushes {
+				t.Errorf("Wanted %d pushes to the subscriber (including the initial one), but got %d", tc.wantPushes, pushes)
+			}
+		})
+	}
+}
+
+func TestSignalLogic(t *testing.T) {
+	testCases := []struct {
+		sigs    []Signal
+		wantAND Signal
+		wantOR  Signal
+	}{
+		{[]Signal{}, Low, Low},
+		{[]Signal{Low, Low}, Low, Low},
+		{[]Signal{High, Low}, Low, High},
+		{[]Signal{High, High}, High, High},
+		{[]Signal{Z, Low}, Low, X},
+		{[]Signal{Z, High}, X, High},
+		{[]Signal{X, Low}, Low, X},
+		{[]Signal{X, High}, X, High},
+		{[]Signal{X, Z}, X, X},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Combining %v", tc.sigs), func(t *testing.T) {
+			if got := andSignals(tc.sigs...); got != tc.wantAND {
+				t.Errorf("Wanted AND signal %s, but got %s", tc.wantAND, got)
+			}
+
+			if got := orSignals(tc.sigs...); got != tc.wantOR {
+				t.Errorf("Wanted OR signal %s, but got %s", tc.wantOR, got)
+			}
+		})
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	testCases := []struct {
+		init        bool
+		actions     string // S=set on, C=set off (clear), T=toggle
+		wantPowered bool
+		wantPushes  int
+	}{
+		{false, "", false, 1},
+		{true, "", true, 1},
+		{false, "S", true, 2},
+		{false, "SS", true, 2},
+		{true, "C", false, 2},
+		{false, "T", true, 2},
+		{false, "TT", false, 3},
+		{true, "TSC", false, 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Starting as %t then performing %q", tc.init, tc.actions), func(t *testing.T) {
+			s := NewSwitch(tc.init)
+
+			pushes := 0
+			s.WireUp(func(Signal) { pushes++ })
+
+			for _, a := range tc.actions {
+				switch a {
+				case 'S':
+					s.Set(true)
+				case 'C':
+					s.Set(false)
+				case 'T':
+					s.Toggle()
+				}
+			}
+
+			if got := s.Emitting(); got != tc.wantPowered {
+				t.Errorf("Wanted power %t, but got %t", tc.wantPowered, got)
+			}
+
+			if pushes != tc.wantPushes {
+				t.Errorf("Wanted %d pushes to the subscriber (including the initial one), but got %d", tc.wantPushes, pushes)
+			}
+		})
+	}
+}
+
 func TestANDContact(t *testing.T) {
 	testCases := []struct {
 		sources []emitter
@@ -63,7 +186,7 @@ func TestXContact(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("Setting source A to %T and source B to %T", tc.sourceA, tc.sourceB), func(t *testing.T) {
-			p := xContact{tc.sourceA, tc.sourceB}
+			p := newXContact(tc.sourceA, tc.sourceB)
 
 			if got := p.Emitting(); got != tc.want {
 				t.Errorf("Wanted power %t, but got %t", tc.want, got)
@@ -304,6 +427,198 @@ func TestXNORGate(t *testing.T) {
 	}
 }
 
+func TestGates_DrivenBySwitches(t *testing.T) {
+	testCases := []struct {
+		aIn      bool
+		bIn      bool
+		wantAND  bool
+		wantOR   bool
+		wantNAND bool
+		wantNOR  bool
+		wantXOR  bool
+		wantXNOR bool
+	}{
+		{false, false, false, false, true, true, false, true},
+		{true, false, false, true, true, false, true, false},
+		{true, true, true, true, false, false, false, true},
+		{false, true, false, true, true, false, true, false},
+		{false, false, false, false, true, true, false, true},
+	}
+
+	aSwitch := NewSwitch(false)
+	bSwitch := NewSwitch(false)
+
+	and := newANDGate(aSwitch, bSwitch)
+	or := newORGate(aSwitch, bSwitch)
+	nand := newNANDGate(aSwitch, bSwitch)
+	nor := newNORGate(aSwitch, bSwitch)
+	xor := newXORGate(aSwitch, bSwitch)
+	xnor := newXNORGate(aSwitch, bSwitch)
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Stage %d: Switching to A (%t) and B (%t)", i+1, tc.aIn, tc.bIn), func(t *testing.T) {
+			aSwitch.Set(tc.aIn)
+			bSwitch.Set(tc.bIn)
+
+			if got := and.Emitting(); got != tc.wantAND {
+				t.Errorf("Wanted AND power %t, but got %t", tc.wantAND, got)
+			}
+			if got := or.Emitting(); got != tc.wantOR {
+				t.Errorf("Wanted OR power %t, but got %t", tc.wantOR, got)
+			}
+			if got := nand.Emitting(); got != tc.wantNAND {
+				t.Errorf("Wanted NAND power %t, but got %t", tc.wantNAND, got)
+			}
+			if got := nor.Emitting(); got != tc.wantNOR {
+				t.Errorf("Wanted NOR power %t, but got %t", tc.wantNOR, got)
+			}
+			if got := xor.Emitting(); got != tc.wantXOR {
+				t.Errorf("Wanted XOR power %t, but got %t", tc.wantXOR, got)
+			}
+			if got := xnor.Emitting(); got != tc.wantXNOR {
+				t.Errorf("Wanted XNOR power %t, but got %t", tc.wantXNOR, got)
+			}
+		})
+	}
+}
+
+func TestGates_FourValued(t *testing.T) {
+	testCases := []struct {
+		aIn      Signal
+		bIn      Signal
+		wantAND  Signal
+		wantOR   Signal
+		wantNAND Signal
+		wantNOR  Signal
+		wantXOR  Signal
+		wantXNOR Signal
+	}{
+		{Low, Low, Low, Low, High, High, Low, High},
+		{High, High, High, High, Low, Low, Low, High},
+		{Z, Low, Low, X, High, X, X, X},
+		{Z, High, X, High, X, Low, X, X},
+		{Low, Z, Low, X, High, X, X, X},
+		{High, Z, X, High, X, Low, X, X},
+		{Z, Z, X, X, X, X, X, X},
+	}
+
+	setSwitch := func(s *Switch, sig Signal) {
+		if sig == Z {
+			s.Float()
+		} else {
+			s.Set(sig == High)
+		}
+	}
+
+	aSwitch := NewSwitch(false)
+	bSwitch := NewSwitch(false)
+
+	and := newANDGate(aSwitch, bSwitch)
+	or := newORGate(aSwitch, bSwitch)
+	nand := newNANDGate(aSwitch, bSwitch)
+	nor := newNORGate(aSwitch, bSwitch)
+	xor := newXORGate(aSwitch, bSwitch)
+	xnor := newXNORGate(aSwitch, bSwitch)
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Stage %d: Switching to A (%s) and B (%s)", i+1, tc.aIn, tc.bIn), func(t *testing.T) {
+			setSwitch(aSwitch, tc.aIn)
+			setSwitch(bSwitch, tc.bIn)
+
+			if got := and.Signal(); got != tc.wantAND {
+				t.Errorf("Wanted AND signal %s, but got %s", tc.wantAND, got)
+			}
+			if got := or.Signal(); got != tc.wantOR {
+				t.Errorf("Wanted OR signal %s, but got %s", tc.wantOR, got)
+			}
+			if got := nand.Signal(); got != tc.wantNAND {
+				t.Errorf("Wanted NAND signal %s, but got %s", tc.wantNAND, got)
+			}
+			if got := nor.Signal(); got != tc.wantNOR {
+				t.Errorf("Wanted NOR signal %s, but got %s", tc.wantNOR, got)
+			}
+			if got := xor.Signal(); got != tc.wantXOR {
+				t.Errorf("Wanted XOR signal %s, but got %s", tc.wantXOR, got)
+			}
+			if got := xnor.Signal(); got != tc.wantXNOR {
+				t.Errorf("Wanted XNOR signal %s, but got %s", tc.wantXNOR, got)
+			}
+		})
+	}
+}
+
+func TestGatesN_AllInputs(t *testing.T) {
+	for _, width := range []int{2, 3, 5} {
+		bus, _ := NewSwitchBus(width, 0)
+
+		and, _ := newANDGateN(bus.wires...)
+		or, _ := newORGateN(bus.wires...)
+		nand, _ := newNANDGateN(bus.wires...)
+		nor, _ := newNORGateN(bus.wires...)
+		xor, _ := newXORGateN(bus.wires...)
+		xnor, _ := newXNORGateN(bus.wires...)
+
+		for v := uint64(0); v < 1<<uint(width); v++ {
+			t.Run(fmt.Sprintf("%d inputs of %0*b", width, width, v), func(t *testing.T) {
+				bus.SetUint(v)
+
+				ones := strings.Count(bus.String(), "1")
+				all := ones == width
+				any := ones > 0
+				odd := ones%2 == 1
+
+				gates := []struct {
+					name string
+					gate emitter
+					want bool
+				}{
+					{"AND", and, all},
+					{"OR", or, any},
+					{"NAND", nand, !all},
+					{"NOR", nor, !any},
+					{"XOR", xor, odd},
+					{"XNOR", xnor, !odd},
+				}
+
+				for _, g := range gates {
+					if got := g.gate.Signal(); got != boolSignal(g.want) {
+						t.Errorf("Wanted %s signal %s, but got %s", g.name, boolSignal(g.want), got)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestGatesN_BadPins(t *testing.T) {
+	wantErr := "An N-input gate needs at least 2 pins, but got 1"
+
+	if _, err := newANDGateN(&Battery{}); err == nil || err.Error() != wantErr {
+		t.Errorf("Wanted error %q from AND, but got %v.", wantErr, err)
+	}
+	if _, err := newXNORGateN(&Battery{}); err == nil || err.Error() != wantErr {
+		t.Errorf("Wanted error %q from XNOR, but got %v.", wantErr, err)
+	}
+}
+
+func TestGatesN_Structure(t *testing.T) {
+	bus, _ := NewSwitchBus(4, 9)
+	xnor, _ := newXNORGateN(bus.wires...)
+	nor, _ := newNORGateN(bus.wires...)
+
+	if got, _ := Probe(xnor, "pin4"); got != High {
+		t.Errorf("Wanted signal %s on pin4, but got %s.", High, got)
+	}
+	if got, _ := Probe(xnor, "xorGate.xorGate3.pin1"); got != High {
+		t.Errorf("Wanted signal %s on the parity of the first three pins, but got %s.", High, got)
+	}
+
+	want := Census{Relays: 4, Batteries: 1, ANDContacts: 4, XContacts: 4, Gates: map[string]int{"NOR (4-input)": 1}}
+	if got := TakeCensus(nor); !reflect.DeepEqual(got, want) {
+		t.Errorf("Wanted census %+v, but got %+v.", want, got)
+	}
+}
+
 func TestHalfAdder(t *testing.T) {
 	testCases := []struct {
 		aIn       emitter
@@ -446,6 +761,78 @@ func TestEightBitAdder_GoodInputs(t *testing.T) {
 	}
 }
 
+func TestEightBitAdder_UpdateInputs(t *testing.T) {
+	testCases := []struct {
+		byte1      string
+		byte2      string
+		carryIn    bool
+		wantAnswer string
+		wantError  string
+	}{
+		{"00000000", "00000000", false, "00000000", ""},
+		{"00000001", "00000001", false, "00000010", ""},
+		{"00000001", "00000001", true, "00000011", ""},
+		{"11111111", "00000001", false, "100000000", ""},
+		{"bad", "00000001", false, "100000000", "First input not in 8-bit binary format:"}, // answer left alone on bad input
+		{"10101010", "01010101", true, "100000000", ""},
+		{"10101010", "01010101", false, "11111111", ""},
+	}
+
+	carryIn := NewSwitch(false)
+
+	a, err := NewEightBitAdder("00000000", "00000000", carryIn)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Stage %d: Switching to %s plus %s with carry in of %t", i+1, tc.byte1, tc.byte2, tc.carryIn), func(t *testing.T) {
+			err := a.UpdateInputs(tc.byte1, tc.byte2)
+			carryIn.Set(tc.carryIn)
+
+			if err != nil && !strings.HasPrefix(err.Error(), tc.wantError) {
+				t.Error("Unexpected error: " + err.Error())
+			}
+
+			if got := a.String(); got != tc.wantAnswer {
+				t.Errorf("Wanted answer %s, but got %s", tc.wantAnswer, got)
+			}
+		})
+	}
+}
+
+func TestEightBitAdder_FloatingCarryIn(t *testing.T) {
+	testCases := []struct {
+		byte1      string
+		byte2      string
+		wantAnswer string
+	}{
+		{"00000000", "00000000", "0000000X"},
+		{"00000001", "00000000", "000000XX"},
+		{"11111110", "00000000", "1111111X"},
+		{"11111111", "00000000", "XXXXXXXXX"}, // unknown carry ripples all the way out
+		{"00001111", "11110000", "XXXXXXXXX"},
+	}
+
+	carryIn := NewSwitch(false)
+	carryIn.Float()
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Adding %s to %s with a floating carry in", tc.byte1, tc.byte2), func(t *testing.T) {
+			a, err := NewEightBitAdder(tc.byte1, tc.byte2, carryIn)
+
+			if err != nil {
+				t.Error("Unexpected error: " + err.Error())
+				return
+			}
+
+			if got := a.String(); got != tc.wantAnswer {
+				t.Errorf("Wanted answer %s, but got %s", tc.wantAnswer, got)
+			}
+		})
+	}
+}
+
 func TestSixteenBitAdder_BadInputs(t *testing.T) {
 	testCases := []struct {
 		bytes1    string
@@ -528,6 +915,37 @@ func TestSixteenBitAdder_GoodInputs(t *testing.T) {
 	}
 }
 
+func TestSixteenBitAdder_UpdateInputs(t *testing.T) {
+	testCases := []struct {
+		bytes1     string
+		bytes2     string
+		wantAnswer string
+	}{
+		{"0000000000000000", "0000000000000000", "0000000000000000"},
+		{"0000000011111111", "0000000000000001", "0000000100000000"},
+		{"1001110110011101", "1101011011010110", "10111010001110011"},
+		{"1111111111111111", "0000000000000001", "10000000000000000"},
+		{"0000000000000001", "0000000000000000", "0000000000000001"},
+	}
+
+	a, err := NewSixteenBitAdder("0000000000000000", "0000000000000000", nil)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Stage %d: Switching to %s plus %s", i+1, tc.bytes1, tc.bytes2), func(t *testing.T) {
+			if err := a.UpdateInputs(tc.bytes1, tc.bytes2); err != nil {
+				t.Error("Unexpected error: " + err.Error())
+			}
+
+			if got := a.String(); got != tc.wantAnswer {
+				t.Errorf("Wanted answer %s, but got %s", tc.wantAnswer, got)
+			}
+		})
+	}
+}
+
 // -stopCh=XXX prevents the test running aspect from finding any tests
 // go test -stopCh=XXX -bench=. -benchmem -count 5 > old.txt
 // ---change some code---
@@ -643,6 +1061,99 @@ func TestOnesCompliment_GoodInputs(t *testing.T) {
 	}
 }
 
+func TestOnesComplementer_Live(t *testing.T) {
+	invert := NewSwitch(false)
+
+	c, err := NewOnesComplementer([]byte("1100"), invert)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	// wired on into the next part of a datapath, following along with every change
+	out := NewBus(c.ComplementBus().wires...)
+
+	testCases := []struct {
+		bits   string
+		invert bool
+		want   string
+	}{
+		{"1100", false, "1100"},
+		{"1100", true, "0011"},
+		{"1010", true, "0101"},
+		{"1010", false, "1010"},
+		{"0000", true, "1111"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Complementing %s with invert %t", tc.bits, tc.invert), func(t *testing.T) {
+			if err := c.UpdateInputs([]byte(tc.bits)); err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+			invert.Set(tc.invert)
+
+			if got := c.Inverting(); got != tc.invert {
+				t.Errorf("Wanted inverting %t, but got %t", tc.invert, got)
+			}
+
+			if got := c.Complement(); got != tc.want {
+				t.Errorf("Wanted %s, but got %s", tc.want, got)
+			}
+
+			if got := out.String(); got != tc.want {
+				t.Errorf("Wanted %s downstream, but got %s", tc.want, got)
+			}
+		})
+	}
+
+	if err := c.UpdateInputs([]byte("111")); err == nil {
+		t.Error("Expected an error for the wrong width of bits")
+	}
+
+	if err := NewOnesComplementerFromBus(out, nil).UpdateInputs([]byte("1111")); err == nil {
+		t.Error("Expected an error updating a complementer driven by a Bus")
+	}
+}
+
+func TestOnesComplementerFromBus_Emitters(t *testing.T) {
+	// the bits and the invert signal all come from other circuits: a half adder's sum and carry, and a comparator's less than
+	a, b := NewSwitch(false), NewSwitch(false)
+	h := newHalfAdder(a, b)
+
+	x, _ := NewSwitchBus(2, 0)
+	y, _ := NewSwitchBus(2, 0)
+	less, _ := NewComparatorFromBuses(x, y, false)
+
+	c := NewOnesComplementerFromBus(NewBus(h.carry, h.sum), less.lt)
+
+	testCases := []struct {
+		a, b bool
+		x, y uint64
+		want string
+	}{
+		{false, false, 0, 0, "00"},
+		{true, false, 0, 0, "01"},
+		{true, true, 0, 0, "10"},
+		{true, true, 1, 2, "01"},
+		{false, false, 1, 2, "11"},
+		{false, true, 2, 1, "01"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%t + %t inverted by %d < %d", tc.a, tc.b, tc.x, tc.y), func(t *testing.T) {
+			Simultaneously(func() {
+				a.Set(tc.a)
+				b.Set(tc.b)
+				x.SetUint(tc.x)
+				y.SetUint(tc.y)
+			})
+
+			if got := c.Complement(); got != tc.want {
+				t.Errorf("Wanted %s, but got %s", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestEightBitSubtracter_BadInputs(t *testing.T) {
 
 	testCases := []struct {
@@ -653,12 +1164,12 @@ func TestEightBitSubtracter_BadInputs(t *testing.T) {
 		{"0000000", "00000000", "First input not in 8-bit binary format:"},  // only 7 bits on first byte
 		{"00000000", "0000000", "Second input not in 8-bit binary format:"}, // only 7 bits on second byte
 		{"bad", "00000000", "First input not in 8-bit binary format:"},
-		{"00000000", "bad", "Input bits not in binary format:"},
-		{"", "", "Input bits not in binary format:"},
+		{"00000000", "bad", "Second input not in 8-bit binary format:"},
+		{"", "", "First input not in 8-bit binary format:"},
 		{"X00000000", "00000000", "First input not in 8-bit binary format:"},
-		{"00000000", "X00000000", "Input bits not in binary format:"},
+		{"00000000", "X00000000", "Second input not in 8-bit binary format:"},
 		{"00000000X", "00000000", "First input not in 8-bit binary format:"},
-		{"00000000", "00000000X", "Input bits not in binary format:"},
+		{"00000000", "00000000X", "Second input not in 8-bit binary format:"},
 	}
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("Subtracting %s from %s", tc.subtrahend, tc.minuend), func(t *testing.T) {
@@ -717,253 +1228,2855 @@ func TestEightBitSubtracter_GoodInputs(t *testing.T) {
 	}
 }
 
-// Fragile test due to timing of asking oscillator vs. state of oscillator at the time being asked
-func TestOscillator(t *testing.T) {
+func TestEightBitSubtractor_Flags(t *testing.T) {
 	testCases := []struct {
-		initState     bool
-		oscHertz      int
-		checkTimes    int
-		wantAllTrues  bool
-		wantAllFalses bool
-		wantTrueFalse bool
+		minuend        string
+		subtrahend     string
+		wantDifference string
+		wantBorrow     bool
+		wantOverflow   bool
 	}{
-		{false, 10, 1, false, true, false},
-		{true, 10, 1, true, false, false},
-		{false, 40, 5, false, false, true},
-		{true, 40, 10, false, false, true},
+		{"00000011", "00000001", "00000010", false, false}, // 3 - 1 = 2
+		{"00000000", "00000001", "11111111", true, false},  // 0 - 1 = -1 signed (or borrowing, 255 unsigned)
+		{"10000000", "00000001", "01111111", false, true},  // -128 - 1 overflows signed (128 - 1 = 127 unsigned)
+		{"01111111", "11111111", "10000000", true, true},   // 127 - -1 overflows signed (127 - 255 borrows unsigned)
+		{"11111110", "11111011", "00000011", false, false}, // -2 - -5 = 3
+		{"11111110", "11111111", "11111111", true, false},  // -2 - -1 = -1
+		{"00000000", "00000000", "00000000", false, false},
 	}
 
 	for _, tc := range testCases {
-		t.Run(fmt.Sprintf("Oscillating at %d hertz, immediate start (%t), checking %d times.", tc.oscHertz, tc.initState, tc.checkTimes), func(t *testing.T) {
-
-			var results string
-
-			o := newOscillator(tc.initState)
-			o.Oscillate(tc.oscHertz)
-
-			for i := 0; i < tc.checkTimes; i++ {
-				if o.Emitting() {
-					results += "T"
-				} else {
-					results += "F"
-				}
-				time.Sleep(time.Millisecond * 500)
+		t.Run(fmt.Sprintf("Subtracting %s from %s", tc.subtrahend, tc.minuend), func(t *testing.T) {
+			s, err := NewEightBitSubtractor(tc.minuend, tc.subtrahend)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
 			}
-			o.Stop()
-
-			gotAllTrues := !strings.Contains(results, "F")
-			gotAllFalses := !strings.Contains(results, "T")
 
-			if (gotAllTrues != tc.wantAllTrues) || (gotAllFalses != tc.wantAllFalses) {
-				t.Errorf(fmt.Sprintf("Wanted all trues (%t), all falses (%t), and mixed (%t), but got results of %s.", tc.wantAllTrues, tc.wantAllFalses, tc.wantTrueFalse, results))
+			if got := s.Difference().String(); got != tc.wantDifference {
+				t.Errorf("Wanted difference %s, but got %s", tc.wantDifference, got)
+			}
+			if got := s.Borrow(); got != tc.wantBorrow {
+				t.Errorf("Wanted borrow %t, but got %t", tc.wantBorrow, got)
+			}
+			if got := s.Overflow(); got != tc.wantOverflow {
+				t.Errorf("Wanted overflow %t, but got %t", tc.wantOverflow, got)
 			}
 		})
 	}
 }
 
-func TestRSFlipFlop_Construction(t *testing.T) {
+func TestSixteenBitSubtractor(t *testing.T) {
 	testCases := []struct {
-		rPin      emitter
-		sPin      emitter
-		wantError string
+		minuend        string
+		subtrahend     string
+		wantDifference string
+		wantBorrow     bool
+		wantOverflow   bool
+		wantError      string
 	}{
-		{nil, nil, ""},
-		{nil, &Battery{}, ""},
-		{&Battery{}, nil, ""},
-		{&Battery{}, &Battery{}, "Both inputs of a Flip-Flop cannot be powered simultaneously"},
+		{"1001110110011101", "0000000000000001", "1001110110011100", false, false, ""},
+		{"0000000000000001", "0000000000000010", "1111111111111111", true, false, ""},
+		{"1000000000000000", "0000000000000001", "0111111111111111", false, true, ""},
+		{"100000000000000", "0000000000000001", "", false, false, "First input not in 16-bit binary format: 100000000000000"},
+		{"1000000000000000", "bad", "", false, false, "Second input not in 16-bit binary format: bad"},
 	}
 
-	for _, tc := range testCases {
-		t.Run(fmt.Sprintf("Creating as rIn (%T) and sIn (%T)", tc.rPin, tc.sPin), func(t *testing.T) {
+	var s *SixteenBitSubtractor
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Stage %d: Subtracting %s from %s", i+1, tc.subtrahend, tc.minuend), func(t *testing.T) {
+			var err error
+			if s == nil {
+				s, err = NewSixteenBitSubtractor(tc.minuend, tc.subtrahend)
+			} else if tc.wantError == "" {
+				err = s.UpdateInputs(tc.minuend, tc.subtrahend) // the same subtractor settles on each new answer
+			} else {
+				_, err = NewSixteenBitSubtractor(tc.minuend, tc.subtrahend)
+			}
+
+			if tc.wantError != "" {
+				if err == nil || err.Error() != tc.wantError {
+					t.Errorf("Wanted error %q, but got %v.", tc.wantError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+			if got := s.Difference().String(); got != tc.wantDifference {
+				t.Errorf("Wanted difference %s, but got %s", tc.wantDifference, got)
+			}
+			if got := s.Borrow(); got != tc.wantBorrow {
+				t.Errorf("Wanted borrow %t, but got %t", tc.wantBorrow, got)
+			}
+			if got := s.Overflow(); got != tc.wantOverflow {
+				t.Errorf("Wanted overflow %t, but got %t", tc.wantOverflow, got)
+			}
+		})
+	}
+}
+
+func TestSubtractor_Widths(t *testing.T) {
+	for _, width := range []int{1, 3} {
+		t.Run(fmt.Sprintf("%d-bit", width), func(t *testing.T) {
+			aBus, _ := NewSwitchBus(width, 0)
+			bBus, _ := NewSwitchBus(width, 0)
+
+			s, err := NewSubtractorFromBuses(aBus, bBus)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			max := int64(1) << uint(width)
+			for a := int64(0); a < max; a++ {
+				for b := int64(0); b < max; b++ {
+					aBus.SetUint(uint64(a))
+					bBus.SetUint(uint64(b))
+
+					signed := func(v int64) int64 {
+						if v >= max/2 {
+							return v - max
+						}
+						return v
+					}
+					signedDiff := signed(a) - signed(b)
+
+					if got, want := s.Difference().Uint(), uint64((a-b+max)%max); got != want {
+						t.Errorf("Wanted %d - %d = %d, but got %d", a, b, want, got)
+					}
+					if got := s.Borrow(); got != (b > a) {
+						t.Errorf("Wanted borrow %t for %d - %d, but got %t", b > a, a, b, got)
+					}
+					if got, want := s.Overflow(), signedDiff < -max/2 || signedDiff >= max/2; got != want {
+						t.Errorf("Wanted overflow %t for %d - %d, but got %t", want, a, b, got)
+					}
+					if got, want := s.Negative(), signed(int64(s.Difference().Uint())) < 0; got != want {
+						t.Errorf("Wanted negative %t for %d - %d, but got %t", want, a, b, got)
+					}
+				}
+			}
+		})
+	}
+
+	if _, err := NewSubtractor(0, "", ""); err == nil {
+		t.Error("Expected an error building a 0-bit subtractor, but got none")
+	}
+
+	wantErr := "Subtractor width must be at least 1, but got 0"
+	if _, err := NewSubtractorFromBuses(NewBus(), NewBus()); err == nil || err.Error() != wantErr {
+		t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+	}
+}
+
+func TestAdderSubtractor(t *testing.T) {
+	testCases := []struct {
+		bits1             string
+		bits2             string
+		sub               bool
+		wantResult        string
+		wantCarryOrBorrow bool
+		wantOverflow      bool
+	}{
+		{"00000011", "00000001", false, "00000100", false, false}, // 3 + 1
+		{"00000011", "00000001", true, "00000010", false, false},  // 3 - 1, only flipping SUB
+		{"00000001", "00000011", true, "11111110", true, false},   // 1 - 3 borrows
+		{"00000001", "00000011", false, "00000100", false, false}, // 1 + 3
+		{"11111111", "00000001", false, "00000000", true, false},  // 255 + 1 carries (-1 + 1 = 0 signed)
+		{"01111111", "00000001", false, "10000000", false, true},  // 127 + 1 overflows signed
+		{"10000000", "00000001", true, "01111111", false, true},   // -128 - 1 overflows signed
+		{"10000000", "00000001", false, "10000001", false, false}, // -128 + 1
+	}
+
+	a, err := NewAdderSubtractor(8, "00000000", "00000000", false)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Stage %d: %s and %s with SUB (%t)", i+1, tc.bits1, tc.bits2, tc.sub), func(t *testing.T) {
+			if err := a.UpdateInputs(tc.bits1, tc.bits2); err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+			if err := a.SetSub(tc.sub); err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			if got := a.Subtracting(); got != tc.sub {
+				t.Errorf("Wanted subtracting %t, but got %t", tc.sub, got)
+			}
+			if got := a.Result().String(); got != tc.wantResult {
+				t.Errorf("Wanted result %s, but got %s", tc.wantResult, got)
+			}
+			if got := a.CarryOrBorrow(); got != tc.wantCarryOrBorrow {
+				t.Errorf("Wanted carry or borrow %t, but got %t", tc.wantCarryOrBorrow, got)
+			}
+			if got := a.Overflow(); got != tc.wantOverflow {
+				t.Errorf("Wanted overflow %t, but got %t", tc.wantOverflow, got)
+			}
+		})
+	}
+}
+
+func TestAdderSubtractorFromBuses(t *testing.T) {
+	aBus, _ := NewSwitchBus(16, 1000)
+	bBus, _ := NewSwitchBus(16, 1234)
+	sub := NewSwitch(true)
+
+	a, err := NewAdderSubtractorFromBuses(aBus, bBus, sub)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	if got := a.Result().Uint(); got != 65302 || !a.CarryOrBorrow() {
+		t.Errorf("Wanted 1000 - 1234 to wrap to 65302 with a borrow, but got %d (borrow %t)", got, a.CarryOrBorrow())
+	}
+
+	sub.Set(false)
+
+	if got := a.Result().Uint(); got != 2234 || a.CarryOrBorrow() {
+		t.Errorf("Wanted 1000 + 1234 = 2234 without a carry, but got %d (carry %t)", got, a.CarryOrBorrow())
+	}
+
+	if got, _ := Probe(a, "comp.xorGates[15]"); got != Low {
+		t.Errorf("Wanted the complementer to pass bits straight through while adding, but got %s", got)
+	}
+
+	if err := a.SetSub(true); err == nil {
+		t.Error("Expected an error setting a SUB line driven from outside, but got none")
+	}
+
+	if _, err := NewAdderSubtractor(8, "0000000", "00000000", false); err == nil {
+		t.Error("Expected an error building from a 7-bit input, but got none")
+	}
+
+	wantErr := "Adder/Subtractor width must be at least 1, but got 0"
+	if _, err := NewAdderSubtractorFromBuses(NewBus(), NewBus(), sub); err == nil || err.Error() != wantErr {
+		t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+	}
+}
+
+func TestMultiplier_AllInputs(t *testing.T) {
+	for _, width := range []int{1, 2, 3, 4} {
+		for _, signed := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%d-bit signed (%t)", width, signed), func(t *testing.T) {
+				aBus, _ := NewSwitchBus(width, 0)
+				bBus, _ := NewSwitchBus(width, 0)
+
+				var m *Multiplier
+				var err error
+				if signed {
+					m, err = NewSignedMultiplierFromBuses(aBus, bBus)
+				} else {
+					m, err = NewMultiplierFromBuses(aBus, bBus)
+				}
+				if err != nil {
+					t.Fatal("Unexpected error: " + err.Error())
+				}
+
+				max := int64(1) << uint(width)
+				value := func(v int64) int64 {
+					if signed && v >= max/2 {
+						return v - max
+					}
+					return v
+				}
+
+				for a := int64(0); a < max; a++ {
+					for b := int64(0); b < max; b++ {
+						aBus.SetUint(uint64(a))
+						bBus.SetUint(uint64(b))
+
+						want := uint64(value(a)*value(b)) & (uint64(max*max) - 1)
+						if got := m.Product().Uint(); got != want {
+							t.Errorf("Wanted %d x %d = %0*b, but got %s", value(a), value(b), width*2, want, m)
+						}
+					}
+				}
+			})
+		}
+	}
+
+	wantErr := "Multiplier width must be at least 1, but got 0"
+	if _, err := NewMultiplierFromBuses(NewBus(), NewBus()); err == nil || err.Error() != wantErr {
+		t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+	}
+	if _, err := NewSignedMultiplierFromBuses(NewBus(), NewBus()); err == nil || err.Error() != wantErr {
+		t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+	}
+}
+
+func TestEightBitMultiplier(t *testing.T) {
+	testCases := []struct {
+		byte1       string
+		byte2       string
+		signed      bool
+		wantProduct string
+		wantError   string
+	}{
+		{"10011101", "11010110", false, "1000001100111110", ""}, // 157 x 214 = 33598
+		{"11111111", "11111111", false, "1111111000000001", ""}, // 255 x 255 = 65025
+		{"11111111", "11111111", true, "0000000000000001", ""},  // -1 x -1 = 1
+		{"10000000", "10000000", true, "0100000000000000", ""},  // -128 x -128 = 16384
+		{"10000000", "01111111", true, "1100000010000000", ""},  // -128 x 127 = -16256
+		{"00000111", "11111101", true, "1111111111101011", ""},  // 7 x -3 = -21
+		{"0000011", "11111101", false, "", "First input not in 8-bit binary format: 0000011"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Multiplying %s by %s signed (%t)", tc.byte1, tc.byte2, tc.signed), func(t *testing.T) {
+			var m *EightBitMultiplier
+			var err error
+			if tc.signed {
+				m, err = NewSignedEightBitMultiplier(tc.byte1, tc.byte2)
+			} else {
+				m, err = NewEightBitMultiplier(tc.byte1, tc.byte2)
+			}
+
+			if tc.wantError != "" {
+				if err == nil || err.Error() != tc.wantError {
+					t.Errorf("Wanted error %q, but got %v.", tc.wantError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+			if got := m.String(); got != tc.wantProduct {
+				t.Errorf("Wanted product %s, but got %s", tc.wantProduct, got)
+			}
+		})
+	}
+}
+
+func TestSixteenBitMultiplier_UpdateInputs(t *testing.T) {
+	m, err := NewSignedSixteenBitMultiplier("0000000000000000", "0000000000000000")
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	if err := m.UpdateInputs("1111111111111110", "0000001111101000"); err != nil { // -2 x 1000
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	if got, want := m.Product().Uint(), uint64(0xFFFFF830); got != want {
+		t.Errorf("Wanted product %08X, but got %08X", want, got)
+	}
+
+	if got := TakeCensus(m).Gates; got["AND"] != 16*16-30+15*16*4 || got["NAND"] != 30+15*16*2 {
+		t.Errorf("Wanted %d ANDs and %d NANDs, but got %v", 16*16-30+15*16*4, 30+15*16*2, got)
+	}
+}
+
+func TestDivider_AllInputs(t *testing.T) {
+	for _, width := range []int{1, 2, 4} {
+		t.Run(fmt.Sprintf("%d-bit", width), func(t *testing.T) {
+			dividend, _ := NewSwitchBus(width, 0)
+			divisor, _ := NewSwitchBus(width, 0)
+
+			d, err := NewDividerFromBuses(dividend, divisor)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			max := uint64(1) << uint(width)
+			for a := uint64(0); a < max; a++ {
+				for b := uint64(0); b < max; b++ {
+					dividend.SetUint(a)
+					divisor.SetUint(b)
+
+					if got := d.DivideByZero(); got != (b == 0) {
+						t.Errorf("Wanted divide by zero %t for %d / %d, but got %t", b == 0, a, b, got)
+					}
+					if b == 0 {
+						continue
+					}
+					if gotQ, gotR := d.Quotient().Uint(), d.Remainder().Uint(); gotQ != a/b || gotR != a%b {
+						t.Errorf("Wanted %d / %d = %d r %d, but got %d r %d", a, b, a/b, a%b, gotQ, gotR)
+					}
+				}
+			}
+		})
+	}
+
+	wantErr := "Divider width must be at least 1, but got 0"
+	if _, err := NewDividerFromBuses(NewBus(), NewBus()); err == nil || err.Error() != wantErr {
+		t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+	}
+}
+
+func TestDivider(t *testing.T) {
+	testCases := []struct {
+		bits1         string
+		bits2         string
+		wantAnswer    string
+		wantDivByZero bool
+		wantError     string
+	}{
+		{"00001010", "00000011", "00000011 r 00000001", false, ""}, // 10 / 3
+		{"11111111", "00000001", "11111111 r 00000000", false, ""}, // 255 / 1
+		{"11111111", "11111111", "00000001 r 00000000", false, ""}, // 255 / 255
+		{"00000101", "10000000", "00000000 r 00000101", false, ""}, // 5 / 128
+		{"11001000", "00001101", "00001111 r 00000101", false, ""}, // 200 / 13
+		{"00000101", "00000000", "11111111 r 00000101", true, ""},  // 5 / 0
+		{"0000101", "00000000", "", false, "First input not in 8-bit binary format: 0000101"},
+	}
+
+	d, err := NewDivider(8, "00000000", "00000001")
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Stage %d: Dividing %s by %s", i+1, tc.bits1, tc.bits2), func(t *testing.T) {
+			err := d.UpdateInputs(tc.bits1, tc.bits2)
+
+			if tc.wantError != "" {
+				if err == nil || err.Error() != tc.wantError {
+					t.Errorf("Wanted error %q, but got %v.", tc.wantError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+			if got := d.String(); got != tc.wantAnswer {
+				t.Errorf("Wanted answer %s, but got %s", tc.wantAnswer, got)
+			}
+			if got := d.DivideByZero(); got != tc.wantDivByZero {
+				t.Errorf("Wanted divide by zero %t, but got %t", tc.wantDivByZero, got)
+			}
+		})
+	}
+
+	if got, _ := Probe(d, "stages[7].sub.borrow"); got != Low {
+		t.Errorf("Wanted the last stage to never borrow when dividing by zero, but got %s", got)
+	}
+}
+
+func TestComparator_AllInputs(t *testing.T) {
+	for _, width := range []int{1, 2, 4} {
+		for _, signed := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%d-bit signed (%t)", width, signed), func(t *testing.T) {
+				aBus, _ := NewSwitchBus(width, 0)
+				bBus, _ := NewSwitchBus(width, 0)
+
+				c, err := NewComparatorFromBuses(aBus, bBus, signed)
+				if err != nil {
+					t.Fatal("Unexpected error: " + err.Error())
+				}
+
+				max := int64(1) << uint(width)
+				value := func(v int64) int64 {
+					if signed && v >= max/2 {
+						return v - max
+					}
+					return v
+				}
+
+				for a := int64(0); a < max; a++ {
+					for b := int64(0); b < max; b++ {
+						aBus.SetUint(uint64(a))
+						bBus.SetUint(uint64(b))
+
+						want := "="
+						if value(a) < value(b) {
+							want = "<"
+						} else if value(a) > value(b) {
+							want = ">"
+						}
+
+						if got := c.String(); got != want {
+							t.Errorf("Wanted %d %s %d, but got %s", value(a), want, value(b), got)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestComparator_Cascade(t *testing.T) {
+	testCases := []struct {
+		a, b   uint64
+		signed bool
+		want   string
+	}{
+		{0x1234, 0x1234, false, "="},
+		{0x1234, 0x1235, false, "<"}, // only the low bytes differ
+		{0x1334, 0x12FF, false, ">"}, // the high bytes win over the low bytes
+		{0x8000, 0x7FFF, false, ">"},
+		{0x8000, 0x7FFF, true, "<"}, // -32768 < 32767
+		{0xFFFF, 0xFFFE, true, ">"}, // -1 > -2
+		{0x00FF, 0xFF00, true, ">"}, // 255 > -256
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Comparing %04X to %04X signed (%t)", tc.a, tc.b, tc.signed), func(t *testing.T) {
+			aBus, _ := NewSwitchBus(16, tc.a)
+			bBus, _ := NewSwitchBus(16, tc.b)
+
+			low, _ := NewComparatorFromBuses(aBus.Slice(8, 16), bBus.Slice(8, 16), false)
+			c, err := low.Cascade(aBus.Slice(0, 8), bBus.Slice(0, 8), tc.signed)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			if c.Width() != 16 {
+				t.Errorf("Wanted a 16-bit cascade, but got %d bits", c.Width())
+			}
+			if got := c.String(); got != tc.want {
+				t.Errorf("Wanted %s, but got %s", tc.want, got)
+			}
+		})
+	}
+
+	signedLow, _ := NewComparator(8, "00000000", "00000000", true)
+	if _, err := signedLow.Cascade(signedLow.bits1, signedLow.bits2, true); err == nil {
+		t.Error("Expected an error cascading a signed comparator, but got none")
+	}
+
+	wantErr := "Comparator width must be at least 1, but got 0"
+	if _, err := NewComparatorFromBuses(NewBus(), NewBus(), false); err == nil || err.Error() != wantErr {
+		t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+	}
+	unsignedLow, _ := NewComparator(8, "00000000", "00000000", false)
+	if _, err := unsignedLow.Cascade(NewBus(), NewBus(), false); err == nil || err.Error() != wantErr {
+		t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+	}
+}
+
+func TestComparator_UpdateInputs(t *testing.T) {
+	c, err := NewComparator(8, "00000000", "00000000", false)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	if got := c.String(); got != "=" {
+		t.Errorf("Wanted =, but got %s", got)
+	}
+
+	c.UpdateInputs("10000000", "01111111")
+	if !c.GreaterThan() || c.Equal() || c.LessThan() {
+		t.Errorf("Wanted only greater than, but got %s", c)
+	}
+
+	if got, _ := Probe(c, "gtTerms[0]"); got != High {
+		t.Errorf("Wanted the leftmost bit to decide, but got %s", got)
+	}
+
+	if err := c.UpdateInputs("1000000", "01111111"); err == nil {
+		t.Error("Expected an error updating with a 7-bit input, but got none")
+	}
+}
+
+func TestBarrelShifter_AllInputs(t *testing.T) {
+	ops := []ShiftOp{ShiftLeft, ShiftRight, ShiftRightArithmetic, RotateLeft, RotateRight}
+
+	for _, width := range []int{1, 3, 4} {
+		for _, op := range ops {
+			t.Run(fmt.Sprintf("%d-bit %s", width, op), func(t *testing.T) {
+				bits, _ := NewSwitchBus(width, 0)
+				amount, _ := NewSwitchBus(3, 0) // can shift past the width too
+
+				s, err := NewBarrelShifterFromBuses(op, bits, amount)
+				if err != nil {
+					t.Fatal("Unexpected error: " + err.Error())
+				}
+
+				mask := uint64(1)<<uint(width) - 1
+
+				for v := uint64(0); v <= mask; v++ {
+					for k := uint64(0); k < 8; k++ {
+						bits.SetUint(v)
+						amount.SetUint(k)
+
+						signed := int64(v<<uint(64-width)) >> uint(64-width)
+						r := k % uint64(width)
+
+						var want, carry uint64
+						switch op {
+						case ShiftLeft:
+							want = v << k & mask
+							if k > 0 && k <= uint64(width) {
+								carry = v >> (uint64(width) - k) & 1
+							}
+						case ShiftRight:
+							want = v >> k
+							if k > 0 && k <= uint64(width) {
+								carry = v >> (k - 1) & 1
+							}
+						case ShiftRightArithmetic:
+							want = uint64(signed>>k) & mask
+							if k > 0 {
+								carry = uint64(signed>>(k-1)) & 1
+							}
+						case RotateLeft:
+							want = (v<<r | v>>(uint64(width)-r)) & mask
+							if k > 0 {
+								carry = v >> (uint64(width) - 1 - (k-1)%uint64(width)) & 1
+							}
+						case RotateRight:
+							want = (v>>r | v<<(uint64(width)-r)) & mask
+							if k > 0 {
+								carry = v >> ((k - 1) % uint64(width)) & 1
+							}
+						}
+
+						if got := s.Result().Uint(); got != want {
+							t.Errorf("Wanted %0*b %s %d to be %0*b, but got %0*b", width, v, op, k, width, want, width, got)
+						}
+
+						if got := s.CarryOut().Emitting(); got != (carry == 1) {
+							t.Errorf("Wanted carry out of %0*b %s %d to be %t, but got %t", width, v, op, k, carry == 1, got)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestBarrelShifter(t *testing.T) {
+	testCases := []struct {
+		op     ShiftOp
+		bits   string
+		amount string
+		want   string
+		carry  bool
+	}{
+		{ShiftLeft, "10110011", "011", "10011000", true},
+		{ShiftLeft, "00000101", "001", "00001010", false}, // 5 * 2 = 10
+		{ShiftLeft, "00000101", "010", "00010100", false}, // 5 * 4 = 20
+		{ShiftRight, "10110011", "011", "00010110", false},
+		{ShiftRight, "10110011", "010", "00101100", true},
+		{ShiftRightArithmetic, "10110011", "011", "11110110", false}, // -77 / 8 rounds down to -10
+		{ShiftRightArithmetic, "01110011", "011", "00001110", false},
+		{RotateLeft, "10110011", "011", "10011101", true},
+		{RotateRight, "10110011", "011", "01110110", false},
+		{RotateRight, "10110011", "000", "10110011", false},
+		{ShiftLeft, "1011", "01", "0110", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s %s by %s", tc.bits, tc.op, tc.amount), func(t *testing.T) {
+			s, err := NewBarrelShifter(tc.op, len(tc.bits), tc.bits, tc.amount)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			if got := s.String(); got != tc.want {
+				t.Errorf("Wanted %s, but got %s", tc.want, got)
+			}
+
+			if got := s.CarryOut().Emitting(); got != tc.carry {
+				t.Errorf("Wanted carry out %t, but got %t", tc.carry, got)
+			}
+		})
+	}
+}
+
+func TestBarrelShifter_UpdateInputs(t *testing.T) {
+	s, err := NewBarrelShifter(ShiftLeft, 8, "00000001", "000")
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	// doubling over and over until the 1 falls off the end
+	for k := 0; k < 8; k++ {
+		s.UpdateInputs("00000001", fmt.Sprintf("%03b", k))
+
+		if got := s.Result().Uint(); got != 1<<uint(k) {
+			t.Errorf("Wanted 1 shifted left by %d to be %d, but got %d", k, 1<<uint(k), got)
+		}
+	}
+
+	s.UpdateInputs("10000000", "001")
+	if got := s.String(); got != "00000000" || !s.CarryOut().Emitting() {
+		t.Errorf("Wanted 00000000 with a carry out, but got %s and %t", got, s.CarryOut().Emitting())
+	}
+
+	if err := s.UpdateInputs("0000001", "001"); err == nil {
+		t.Error("Expected an error for the wrong width of bits")
+	}
+
+	if err := s.UpdateInputs("00000001", "1"); err == nil {
+		t.Error("Expected an error for the wrong width of shift amount")
+	}
+
+	if _, err := NewBarrelShifter(ShiftLeft, 4, "0120", "01"); err == nil {
+		t.Error("Expected an error for bits not in binary")
+	}
+
+	if _, err := NewBarrelShifter(ShiftLeft, 4, "0110", ""); err == nil {
+		t.Error("Expected an error for a missing shift amount")
+	}
+
+	for _, op := range []ShiftOp{ShiftOp(-1), ShiftOp(5)} {
+		wantErr := fmt.Sprintf("Unknown shift operation: ShiftOp(%d)", int(op))
+
+		if _, err := NewBarrelShifter(op, 4, "0110", "01"); err == nil || err.Error() != wantErr {
+			t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+		}
+
+		bits, _ := NewSwitchBus(4, 6)
+		amount, _ := NewSwitchBus(1, 1)
+		if _, err := NewBarrelShifterFromBuses(op, bits, amount); err == nil || err.Error() != wantErr {
+			t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+		}
+	}
+
+	amount, _ := NewSwitchBus(1, 1)
+	wantErr := "Shifter width must be at least 1, but got 0"
+	if _, err := NewBarrelShifterFromBuses(RotateRight, NewBus(), amount); err == nil || err.Error() != wantErr {
+		t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+	}
+
+	bits, _ := NewSwitchBus(4, 6)
+	wantErr = "Shift amount width must be at least 1, but got 0"
+	if _, err := NewBarrelShifterFromBuses(ShiftLeft, bits, NewBus()); err == nil || err.Error() != wantErr {
+		t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+	}
+}
+
+func TestALU_AllInputs(t *testing.T) {
+	for _, width := range []int{1, 3, 4} {
+		t.Run(fmt.Sprintf("%d-bit", width), func(t *testing.T) {
+			aBus, _ := NewSwitchBus(width, 0)
+			bBus, _ := NewSwitchBus(width, 0)
+			opBus, _ := NewSwitchBus(4, 0)
+			carryIn := NewSwitch(false)
+
+			alu, err := NewALUFromBuses(aBus, bBus, opBus, carryIn)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			mask := int64(1)<<uint(width) - 1
+			signed := func(v int64) int64 {
+				if v > mask/2 {
+					return v - mask - 1
+				}
+				return v
+			}
+			fits := func(v int64) bool {
+				return v >= -(mask/2)-1 && v <= mask/2
+			}
+
+			for op := ALUOp(0); op < 16; op++ {
+				opBus.SetUint(uint64(op))
+
+				for a := int64(0); a <= mask; a++ {
+					for b := int64(0); b <= mask; b++ {
+						for _, c := range []int64{0, 1} {
+							Simultaneously(func() {
+								aBus.SetUint(uint64(a))
+								bBus.SetUint(uint64(b))
+								carryIn.Set(c == 1)
+							})
+
+							var want, signedWant int64
+							switch op {
+							case ADD:
+								want, signedWant = a+b, signed(a)+signed(b)
+							case ADC:
+								want, signedWant = a+b+c, signed(a)+signed(b)+c
+							case INC:
+								want, signedWant = a+1, signed(a)+1
+							case SUB, CMP:
+								want, signedWant = a-b, signed(a)-signed(b)
+							case SBB:
+								want, signedWant = a-b-c, signed(a)-signed(b)-c
+							case DEC:
+								want, signedWant = a-1, signed(a)-1
+							case AND:
+								want = a & b
+							case OR:
+								want = a | b
+							case XOR:
+								want = a ^ b
+							case NOT:
+								want = ^a & mask
+							}
+
+							carry := want < 0 || want > mask
+							overflow := !fits(signedWant)
+							want &= mask
+
+							ones := 0
+							for v := want; v > 0; v >>= 1 {
+								ones += int(v & 1)
+							}
+
+							desc := fmt.Sprintf("%d %s %d (carry in %d)", a, op, b, c)
+
+							if got := int64(alu.Result().Uint()); got != want {
+								t.Errorf("Wanted %s to be %d, but got %d", desc, want, got)
+							}
+							if got := alu.Zero(); got != (want == 0) {
+								t.Errorf("Wanted zero flag of %s to be %t, but got %t", desc, want == 0, got)
+							}
+							if got := alu.Carry(); got != carry {
+								t.Errorf("Wanted carry flag of %s to be %t, but got %t", desc, carry, got)
+							}
+							if got := alu.Sign(); got != (want>>uint(width-1) == 1) {
+								t.Errorf("Wanted sign flag of %s to be %t, but got %t", desc, want>>uint(width-1) == 1, got)
+							}
+							if got := alu.Parity(); got != (ones%2 == 0) {
+								t.Errorf("Wanted parity flag of %s to be %t, but got %t", desc, ones%2 == 0, got)
+							}
+							if got := alu.Overflow(); got != overflow {
+								t.Errorf("Wanted overflow flag of %s to be %t, but got %t", desc, overflow, got)
+							}
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestALU(t *testing.T) {
+	testCases := []struct {
+		op      ALUOp
+		bits1   string
+		bits2   string
+		carryIn bool
+		want    string
+		flags   string
+	}{
+		{ADD, "01111111", "00000001", false, "10000000", "--S-O"}, // 127 + 1 overflows
+		{ADD, "11111111", "00000001", false, "00000000", "ZC-P-"},
+		{ADC, "00000001", "00000001", true, "00000011", "---P-"},
+		{SUB, "00000011", "00000101", false, "11111110", "-CS--"}, // 3 - 5 borrows
+		{SBB, "00000101", "00000011", true, "00000001", "-----"},
+		{CMP, "00000101", "00000101", false, "00000000", "Z--P-"},
+		{AND, "11110000", "10101010", false, "10100000", "--SP-"},
+		{OR, "11110000", "00001111", false, "11111111", "--SP-"},
+		{XOR, "11110000", "10101010", false, "01011010", "---P-"},
+		{NOT, "11110000", "10101010", false, "00001111", "---P-"},
+		{INC, "11111111", "00000000", false, "00000000", "ZC-P-"},
+		{DEC, "10000000", "00000000", false, "01111111", "----O"}, // -128 - 1 overflows
+		{DEC, "00000000", "11111111", true, "11111111", "-CSP-"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s %s %s", tc.bits1, tc.op, tc.bits2), func(t *testing.T) {
+			alu, err := NewALU(8, tc.bits1, tc.bits2, tc.op, tc.carryIn)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			if got := alu.String(); got != tc.want {
+				t.Errorf("Wanted %s, but got %s", tc.want, got)
+			}
+
+			if got := alu.Flags(); got != tc.flags {
+				t.Errorf("Wanted flags %s, but got %s", tc.flags, got)
+			}
+		})
+	}
+}
+
+func TestALU_SetOp(t *testing.T) {
+	alu, err := NewALU(8, "00001100", "00001010", ADD, false)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	// the same ALU, just switched between operations
+	for _, tc := range []struct {
+		op   ALUOp
+		want uint64
+	}{
+		{ADD, 22}, {SUB, 2}, {AND, 8}, {OR, 14}, {XOR, 6}, {NOT, 243}, {INC, 13}, {DEC, 11},
+	} {
+		alu.SetOp(tc.op)
+
+		if got := alu.Op(); got != tc.op {
+			t.Errorf("Wanted op %s, but got %s", tc.op, got)
+		}
+
+		if got := alu.Result().Uint(); got != tc.want {
+			t.Errorf("Wanted 12 %s 10 to be %d, but got %d", tc.op, tc.want, got)
+		}
+	}
+
+	alu.SetOp(ADC)
+	alu.SetCarryIn(true)
+	alu.UpdateInputs("11111111", "00000000")
+	if got := alu.Flags(); got != "ZC-P-" {
+		t.Errorf("Wanted 255 + 0 + 1 to set flags ZC-P-, but got %s", got)
+	}
+
+	for _, op := range []ALUOp{ALUOp(-1), ALUOp(11), ALUOp(15), ALUOp(16)} {
+		wantErr := fmt.Sprintf("Unknown ALU operation: ALUOp(%d)", int(op))
+
+		if err := alu.SetOp(op); err == nil || err.Error() != wantErr {
+			t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+		}
+
+		if _, err := NewALU(8, "00001100", "00001010", op, false); err == nil || err.Error() != wantErr {
+			t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+		}
+	}
+
+	if got := alu.Op(); got != ADC {
+		t.Errorf("Wanted a rejected opcode to leave the ALU on %s, but got %s", ADC, got)
+	}
+
+	if err := alu.UpdateInputs("1111111", "00000000"); err == nil {
+		t.Error("Expected an error for the wrong width of bits")
+	}
+
+	if _, err := NewALU(0, "", "", ADD, false); err == nil {
+		t.Error("Expected an error for a width of 0")
+	}
+
+	aBus, _ := NewSwitchBus(8, 0)
+	bBus, _ := NewSwitchBus(8, 0)
+	opBus, _ := NewSwitchBus(3, 0)
+	if _, err := NewALUFromBuses(aBus, bBus, opBus, nil); err == nil {
+		t.Error("Expected an error for a 3-bit opcode")
+	}
+
+	opBus, _ = NewSwitchBus(4, 0)
+	wantErr := "ALU width must be at least 1, but got 0"
+	if _, err := NewALUFromBuses(NewBus(), NewBus(), opBus, nil); err == nil || err.Error() != wantErr {
+		t.Errorf("Wanted error %q, but got %v.", wantErr, err)
+	}
+
+	fromBuses, _ := NewALUFromBuses(aBus, aBus, aBus.Slice(0, 4), nil)
+	if err := fromBuses.SetOp(ADD); err == nil {
+		t.Error("Expected an error setting the opcode of an ALU driven by Buses")
+	}
+}
+
+func TestBCDAdder_AllInputs(t *testing.T) {
+	a, err := NewBCDAdder("00000000", "00000000", nil)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	for x := 0; x < 100; x++ {
+		for y := 0; y < 100; y++ {
+			bits1, _ := DecimalToBCD(strconv.Itoa(x), 2)
+			bits2, _ := DecimalToBCD(strconv.Itoa(y), 2)
+			a.UpdateInputs(bits1, bits2)
+
+			want := fmt.Sprintf("%02d", x+y)
+			if got := a.Decimal(); got != want {
+				t.Errorf("Wanted %d + %d = %s, but got %s", x, y, want, got)
+			}
+		}
+	}
+}
+
+func TestBCDAdder(t *testing.T) {
+	testCases := []struct {
+		decimal1, decimal2 string
+		carryIn            emitter
+		want               string
+	}{
+		{"0", "0", nil, "0"},
+		{"7", "5", nil, "12"},
+		{"9", "9", &Battery{}, "19"},
+		{"49", "35", nil, "84"},
+		{"99", "1", nil, "100"},
+		{"1234", "8766", nil, "10000"},
+		{"5678", "1234", &Battery{}, "6913"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Adding %s to %s with carry in of %v", tc.decimal1, tc.decimal2, tc.carryIn), func(t *testing.T) {
+			digits := len(tc.decimal1)
+			if len(tc.decimal2) > digits {
+				digits = len(tc.decimal2)
+			}
+
+			bits1, _ := DecimalToBCD(tc.decimal1, digits)
+			bits2, _ := DecimalToBCD(tc.decimal2, digits)
+
+			a, err := NewBCDAdder(bits1, bits2, tc.carryIn)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			got := strings.TrimLeft(a.Decimal(), "0")
+			if got == "" {
+				got = "0"
+			}
+
+			if got != tc.want {
+				t.Errorf("Wanted %s, but got %s (%s)", tc.want, got, a)
+			}
+		})
+	}
+}
+
+func TestBCDAdder_BadInputs(t *testing.T) {
+	testCases := []struct {
+		bits1, bits2 string
+	}{
+		{"1010", "0000"},     // 10 isn't a decimal digit
+		{"0000", "1111"},     // neither is 15
+		{"000", "000"},       // not a whole digit
+		{"00000000", "0000"}, // not the same width
+		{"", ""},
+		{"0000abcd", "00000000"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Adding %q to %q", tc.bits1, tc.bits2), func(t *testing.T) {
+			if _, err := NewBCDAdder(tc.bits1, tc.bits2, nil); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+
+	if _, err := NewBCDAdderFromBuses(NewBus(nil, nil, nil), NewBus(nil, nil, nil), nil); err == nil {
+		t.Error("Expected an error for Buses that aren't a whole digit wide")
+	}
+
+	d, _ := NewBCDDigitAdder("0111", "0101", nil)
+	if got := d.String(); got != "10010" {
+		t.Errorf("Wanted 7 + 5 to be 10010, but got %s", got)
+	}
+
+	if err := d.UpdateInputs("1100", "0000"); err == nil {
+		t.Error("Expected an error for a digit of 12")
+	}
+}
+
+func TestDecimalAdjuster_AllInputs(t *testing.T) {
+	// the same way an 8080 would do it, a binary add of two BCD bytes then a DAA
+	bus1, _ := NewSwitchBus(8, 0)
+	bus2, _ := NewSwitchBus(8, 0)
+
+	a, _ := NewEightBitAdderFromBuses(bus1, bus2, nil)
+	lowDigits, _ := NewAdderFromBuses(bus1.Slice(4, 8), bus2.Slice(4, 8), nil)
+
+	d, err := NewDecimalAdjusterFromBuses(a.Sum(), a.CarryOut(), lowDigits.CarryOut())
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	for x := 0; x < 100; x++ {
+		for y := 0; y < 100; y++ {
+			bits1, _ := DecimalToBCD(strconv.Itoa(x), 2)
+			bits2, _ := DecimalToBCD(strconv.Itoa(y), 2)
+			Simultaneously(func() {
+				bus1.setBits(bits1)
+				bus2.setBits(bits2)
+			})
+
+			want, _ := DecimalToBCD(strconv.Itoa((x+y)%100), 2)
+			if got := d.Result().String(); got != want {
+				t.Errorf("Wanted %d + %d adjusted to %s, but got %s", x, y, want, got)
+			}
+
+			if got := d.CarryOut().Emitting(); got != (x+y >= 100) {
+				t.Errorf("Wanted carry out of %d + %d to be %t, but got %t", x, y, x+y >= 100, got)
+			}
+		}
+	}
+}
+
+func TestDecimalAdjuster(t *testing.T) {
+	testCases := []struct {
+		bits            string
+		carry, auxCarry bool
+		want            string
+	}{
+		{"01111110", false, false, "10000100"}, // 49 + 35 = 7E, adjusted to 84
+		{"00010010", false, true, "00011000"},  // 09 + 09 = 12 with aux carry, adjusted to 18
+		{"10011001", false, false, "10011001"}, // already 99
+		{"00110010", true, true, "110011000"},  // 99 + 99 = 132 (both carries), adjusted to 1 98
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Adjusting %s with carry %t and aux carry %t", tc.bits, tc.carry, tc.auxCarry), func(t *testing.T) {
+			d, err := NewDecimalAdjuster(tc.bits, tc.carry, tc.auxCarry)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			if got := d.String(); got != tc.want {
+				t.Errorf("Wanted %s, but got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDecimalToBCD(t *testing.T) {
+	testCases := []struct {
+		decimal string
+		digits  int
+		want    string
+		wantErr bool
+	}{
+		{"0", 1, "0000", false},
+		{"42", 4, "0000000001000010", false},
+		{"1999", 4, "0001100110011001", false},
+		{"12345", 4, "", true},
+		{"12a", 4, "", true},
+		{"", 2, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%q as %d digits", tc.decimal, tc.digits), func(t *testing.T) {
+			got, err := DecimalToBCD(tc.decimal, tc.digits)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Wanted error %t, but got %v", tc.wantErr, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("Wanted %s, but got %s", tc.want, got)
+			}
+
+			if err == nil {
+				if back, _ := BCDToDecimal(got); strings.TrimLeft(back, "0") != strings.TrimLeft(tc.decimal, "0") {
+					t.Errorf("Wanted %s back, but got %s", tc.decimal, back)
+				}
+			}
+		})
+	}
+}
+
+func TestIncDecNeg_AllInputs(t *testing.T) {
+	for _, width := range []int{1, 2, 4, 6} {
+		t.Run(fmt.Sprintf("%d-bit", width), func(t *testing.T) {
+			bus, _ := NewSwitchBus(width, 0)
+
+			inc := NewIncrementerFromBus(bus)
+			dec := NewDecrementerFromBus(bus)
+			neg := NewNegatorFromBus(bus)
+
+			mask := int64(1)<<uint(width) - 1
+			min, max := -(mask/2)-1, mask/2
+			signed := func(v int64) int64 {
+				if v > max {
+					return v - mask - 1
+				}
+				return v
+			}
+
+			for v := int64(0); v <= mask; v++ {
+				bus.SetUint(uint64(v))
+
+				if got, want := int64(inc.Result().Uint()), (v+1)&mask; got != want {
+					t.Errorf("Wanted %d + 1 to be %d, but got %d", v, want, got)
+				}
+				if got, want := inc.CarryOut().Emitting(), v == mask; got != want {
+					t.Errorf("Wanted carry out of %d + 1 to be %t, but got %t", v, want, got)
+				}
+				if got, want := inc.Overflow(), signed(v) == max; got != want {
+					t.Errorf("Wanted overflow of %d + 1 to be %t, but got %t", signed(v), want, got)
+				}
+
+				if got, want := int64(dec.Result().Uint()), (v-1)&mask; got != want {
+					t.Errorf("Wanted %d - 1 to be %d, but got %d", v, want, got)
+				}
+				if got, want := dec.BorrowOut().Emitting(), v == 0; got != want {
+					t.Errorf("Wanted borrow out of %d - 1 to be %t, but got %t", v, want, got)
+				}
+				if got, want := dec.Overflow(), signed(v) == min; got != want {
+					t.Errorf("Wanted overflow of %d - 1 to be %t, but got %t", signed(v), want, got)
+				}
+
+				if got, want := int64(neg.Result().Uint()), -v&mask; got != want {
+					t.Errorf("Wanted -%d to be %d, but got %d", v, want, got)
+				}
+				if got, want := neg.Overflow(), signed(v) == min; got != want {
+					t.Errorf("Wanted overflow of -%d to be %t, but got %t", signed(v), want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIncDecNeg(t *testing.T) {
+	testCases := []struct {
+		bits                      string
+		wantInc, wantDec, wantNeg string
+		incOverflow, decOverflow  bool
+	}{
+		{"00000000", "00000001", "11111111", "00000000", false, false},
+		{"00000011", "00000100", "00000010", "11111101", false, false},
+		{"01111111", "10000000", "01111110", "10000001", true, false},
+		{"10000000", "10000001", "01111111", "10000000", false, true},
+		{"11111111", "00000000", "11111110", "00000001", false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.bits, func(t *testing.T) {
+			inc, err := NewIncrementer(8, tc.bits)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+			dec, _ := NewDecrementer(8, tc.bits)
+			neg, _ := NewNegator(8, tc.bits)
+
+			if got := inc.String(); got != tc.wantInc {
+				t.Errorf("Wanted %s + 1 to be %s, but got %s", tc.bits, tc.wantInc, got)
+			}
+			if got := dec.String(); got != tc.wantDec {
+				t.Errorf("Wanted %s - 1 to be %s, but got %s", tc.bits, tc.wantDec, got)
+			}
+			if got := neg.String(); got != tc.wantNeg {
+				t.Errorf("Wanted -%s to be %s, but got %s", tc.bits, tc.wantNeg, got)
+			}
+
+			if got := inc.Overflow(); got != tc.incOverflow {
+				t.Errorf("Wanted increment overflow %t, but got %t", tc.incOverflow, got)
+			}
+			if got := dec.Overflow(); got != tc.decOverflow {
+				t.Errorf("Wanted decrement overflow %t, but got %t", tc.decOverflow, got)
+			}
+		})
+	}
+
+	if _, err := NewIncrementer(3, "01a"); err == nil {
+		t.Error("Expected an error for bits not in binary")
+	}
+
+	if _, err := NewDecrementer(8, "0000"); err == nil {
+		t.Error("Expected an error for the wrong width of bits")
+	}
+
+	if _, err := NewNegator(0, ""); err == nil || err.Error() != "Negator width must be at least 1, but got 0" {
+		t.Errorf("Expected an error for a width of 0, but got %v", err)
+	}
+
+	inc, _ := NewIncrementer(4, "0000")
+	if err := inc.UpdateInputs("00000"); err == nil {
+		t.Error("Expected an error for the wrong width of bits")
+	}
+}
+
+func TestIncrementer_Counter(t *testing.T) {
+	// a program counter of sorts, the incremented value fed back in as the next count
+	pc, _ := NewSwitchBus(4, 0)
+	inc := NewIncrementerFromBus(pc)
+
+	for count := uint64(1); count <= 20; count++ {
+		pc.SetUint(inc.Result().Uint())
+
+		if got := pc.Uint(); got != count%16 {
+			t.Errorf("Wanted a count of %d, but got %d", count%16, got)
+		}
+	}
+}
+
+func TestParseOperand(t *testing.T) {
+	testCases := []struct {
+		operand   string
+		width     int
+		want      string
+		wantError string
+	}{
+		{"00101010", 8, "00101010", ""},
+		{"0x2A", 8, "00101010", ""},
+		{"0X2a", 8, "00101010", ""},
+		{"0o52", 8, "00101010", ""},
+		{"0b101010", 8, "00101010", ""},
+		{"0d42", 8, "00101010", ""},
+		{"42", 8, "00101010", ""},
+		{"+42", 8, "00101010", ""},
+		{"-42", 8, "11010110", ""},
+		{"-1", 16, "1111111111111111", ""},
+		{"0xFF_FF", 16, "1111111111111111", ""},
+		{"0x_FF", 8, "11111111", ""},
+		{"1_000", 16, "0000001111101000", ""},
+		{"0b1010_0101", 8, "10100101", ""},
+		{"0x0000FF", 8, "11111111", ""}, // leading 0s don't count against the width
+		{"255", 8, "11111111", ""},
+		{"-128", 8, "10000000", ""},
+		{"0x1_0000_0000_0000_0000", 72, "000000010000000000000000000000000000000000000000000000000000000000000000", ""},
+		{"256", 8, "", "Operand 256 does not fit in 8 bits"},
+		{"-129", 8, "", "Operand -129 does not fit in 8 bits"},
+		{"0x100", 8, "", "Operand 0x100 does not fit in 8 bits"},
+		{"0xFG", 8, "", "Operand not in hexadecimal format: 0xFG"},
+		{"0o8", 8, "", "Operand not in octal format: 0o8"},
+		{"0b102", 8, "", "Operand not in binary format: 0b102"},
+		{"0xF__F", 8, "", "Operand not in hexadecimal format: 0xF__F"},
+		{"0xFF_", 8, "", "Operand not in hexadecimal format: 0xFF_"},
+		{"1__0", 8, "", "Operand not a 8-bit number: 1__0"},
+		{"10", 8, "", "Operand not a 8-bit number: 10"}, // just 0s and 1s, so read as bits
+		{"bad", 8, "", "Operand not a 8-bit number: bad"},
+		{"", 8, "", "Operand not a 8-bit number: "},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Parsing %q as %d bits", tc.operand, tc.width), func(t *testing.T) {
+			got, err := ParseOperand(tc.operand, tc.width)
+
+			if err != nil && err.Error() != tc.wantError {
+				t.Errorf("Wanted error %q, but got %q", tc.wantError, err.Error())
+			}
+			if err == nil && tc.wantError != "" {
+				t.Errorf("Wanted error %q, but got none", tc.wantError)
+			}
+
+			if got != tc.want {
+				t.Errorf("Wanted %s, but got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatBits(t *testing.T) {
+	testCases := []struct {
+		bits   string
+		format NumberFormat
+		want   string
+	}{
+		{"11111110", Binary, "11111110"},
+		{"11111110", Hexadecimal, "0xFE"},
+		{"00000000", Hexadecimal, "0x00"},
+		{"111111110", Hexadecimal, "0x1FE"},
+		{"11111110", Octal, "0o376"},
+		{"000001", Octal, "0o01"},
+		{"11111110", Decimal, "254"},
+		{"11111110", SignedDecimal, "-2"},
+		{"01111111", SignedDecimal, "127"},
+		{"1", SignedDecimal, "-1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Formatting %s as %s", tc.bits, tc.format), func(t *testing.T) {
+			got, err := FormatBits(tc.bits, tc.format)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			if got != tc.want {
+				t.Errorf("Wanted %s, but got %s", tc.want, got)
+			}
+
+			// and back again
+			if tc.format != Decimal && tc.format != SignedDecimal {
+				if back, _ := ParseOperand(got, len(tc.bits)); back != tc.bits {
+					t.Errorf("Wanted %s back, but got %s", tc.bits, back)
+				}
+			}
+		})
+	}
+
+	if _, err := FormatBits("10X", Hexadecimal); err == nil {
+		t.Error("Expected an error for bits not in binary")
+	}
+
+	if f, err := ParseNumberFormat("hex"); err != nil || f != Hexadecimal {
+		t.Errorf("Wanted hex to be Hexadecimal, but got %s (%v)", f, err)
+	}
+
+	if _, err := ParseNumberFormat("roman"); err == nil {
+		t.Error("Expected an error for an unknown number format")
+	}
+
+	bus, _ := NewSwitchBus(16, 0xBEEF)
+	if got := bus.Format(Hexadecimal); got != "0xBEEF" {
+		t.Errorf("Wanted 0xBEEF, but got %s", got)
+	}
+}
+
+func TestOperands_Constructors(t *testing.T) {
+	a8, err := NewEightBitAdder("0x0F", "0d1", nil)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+	if got := a8.Sum().Format(Hexadecimal); got != "0x10" {
+		t.Errorf("Wanted 0x0F + 1 to be 0x10, but got %s", got)
+	}
+
+	a8.UpdateInputs("-1", "+1")
+	if got := a8.String(); got != "100000000" {
+		t.Errorf("Wanted -1 + 1 to be 100000000, but got %s", got)
+	}
+
+	a16, err := NewSixteenBitAdder("1_000", "0o1750", nil)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+	if got := a16.Sum().Format(Decimal); got != "2000" {
+		t.Errorf("Wanted 1000 + 1000 to be 2000, but got %s", got)
+	}
+
+	s8, err := NewEightBitSubtractor("-100", "27")
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+	if got := s8.Difference().Format(SignedDecimal); got != "-127" {
+		t.Errorf("Wanted -100 - 27 to be -127, but got %s", got)
+	}
+
+	m, err := NewSignedEightBitMultiplier("-3", "0d5")
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+	if got := m.Product().Format(SignedDecimal); got != "-15" {
+		t.Errorf("Wanted -3 x 5 to be -15, but got %s", got)
+	}
+
+	c, err := NewOnesComplementer([]byte("0xF0"), &Battery{})
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+	if got := c.Complement(); got != "00001111" {
+		t.Errorf("Wanted 0xF0 complemented to be 00001111, but got %s", got)
+	}
+
+	if _, err := NewOnesComplementer([]byte("42"), &Battery{}); err == nil {
+		t.Error("Expected an error for a decimal operand with no width")
+	}
+
+	inc, err := NewIncrementer(8, "0d5")
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+	if got := inc.Result().Format(Decimal); got != "6" {
+		t.Errorf("Wanted 5 + 1 to be 6, but got %s", got)
+	}
+
+	neg, err := NewNegator(16, "+1000")
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+	if got := neg.Result().Format(SignedDecimal); got != "-1000" {
+		t.Errorf("Wanted -(1000) to be -1000, but got %s", got)
+	}
+
+	if _, err := NewEightBitAdder("0x100", "0", nil); err == nil || err.Error() != "Operand 0x100 does not fit in 8 bits" {
+		t.Errorf("Expected an error for an operand too big for 8 bits, but got %v", err)
+	}
+
+	s, err := NewBarrelShifter(ShiftLeft, 8, "0x01", "0b000")
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+	s.UpdateInputs("0x03", "2")
+	if got := s.Result().Format(Hexadecimal); got != "0x0C" {
+		t.Errorf("Wanted 0x03 << 2 to be 0x0C, but got %s", got)
+	}
+}
+
+func TestFloat16Conversions(t *testing.T) {
+	for h := 0; h < 1<<16; h++ {
+		f := Float16ToFloat64(uint16(h))
+
+		if math.IsNaN(f) {
+			if h&0x7C00 != 0x7C00 || h&0x3FF == 0 {
+				t.Errorf("Wanted %04X to be a number, but got NaN", h)
+			}
+			continue
+		}
+
+		if got := Float64ToFloat16(f); got != uint16(h) {
+			t.Errorf("Wanted %v back as %04X, but got %04X", f, h, got)
+		}
+	}
+
+	testCases := []struct {
+		f    float64
+		want uint16
+	}{
+		{1, 0x3C00},
+		{-2, 0xC000},
+		{65504, 0x7BFF},
+		{65519, 0x7BFF},                  // rounds down to the biggest number
+		{65520, 0x7C00},                  // rounds up to infinity
+		{1 + math.Ldexp(1, -11), 0x3C00}, // exactly halfway, rounds to even
+		{1 + math.Ldexp(3, -11), 0x3C02}, // exactly halfway, rounds to even
+		{math.Ldexp(1, -25), 0x0000},     // halfway to the smallest subnormal, rounds to even
+		{math.Ldexp(3, -25), 0x0002},
+		{math.Copysign(0, -1), 0x8000},
+		{math.NaN(), 0x7E00},
+	}
+
+	for _, tc := range testCases {
+		if got := Float64ToFloat16(tc.f); got != tc.want {
+			t.Errorf("Wanted %v to be %04X, but got %04X", tc.f, tc.want, got)
+		}
+	}
+}
+
+func TestHalfPrecisionAdder(t *testing.T) {
+	testCases := []struct {
+		bits1, bits2 uint16
+		desc         string
+	}{
+		{0x3C00, 0x3C00, "1 + 1"},
+		{0x3C00, 0xBC00, "1 - 1 is +0"},
+		{0x8000, 0x8000, "-0 + -0 is -0"},
+		{0x0000, 0x8000, "0 + -0 is +0"},
+		{0x3C00, 0x0000, "1 + 0"},
+		{0x4248, 0x3E00, "3.140625 + 1.5"},
+		{0x3C00, 0x1000, "1 + 2^-11 rounds to even (down)"},
+		{0x3C01, 0x1000, "1.0009765625 + 2^-11 rounds to even (up)"},
+		{0x3C00, 0x1001, "1 + a bit more than 2^-11 rounds up"},
+		{0x3C00, 0x8001, "1 - the smallest subnormal"},
+		{0x4000, 0xBBFF, "2 - 0.99951171875 cancels most of the bits"},
+		{0x7BFF, 0x7BFF, "the biggest number doubled overflows to infinity"},
+		{0x7BFF, 0x5000, "the biggest number rounds up to infinity"},
+		{0x7BFF, 0x4FFF, "the biggest number stays put"},
+		{0x0001, 0x0001, "subnormals add exactly"},
+		{0x03FF, 0x0001, "subnormals add up to the smallest normal number"},
+		{0x0400, 0x8001, "the smallest normal number less a subnormal is subnormal"},
+		{0x7C00, 0x3C00, "infinity + 1"},
+		{0xFC00, 0x7BFF, "-infinity + the biggest number"},
+		{0x7C00, 0x7C00, "infinity + infinity"},
+		{0x7C00, 0xFC00, "infinity - infinity is NaN"},
+		{0x7E00, 0x3C00, "NaN + 1"},
+		{0x3C00, 0x7C01, "1 + NaN"},
+		{0xC500, 0x4500, "-5 + 5"},
+		{0xC500, 0x4400, "-5 + 4"},
+		{0x3555, 0x3555, "a third + a third"},
+		{0x7800, 0x0001, "a big number + the smallest subnormal"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%04X + %04X (%s)", tc.bits1, tc.bits2, tc.desc), func(t *testing.T) {
+			h, err := NewHalfPrecisionAdder(fmt.Sprintf("0x%04X", tc.bits1), fmt.Sprintf("0x%04X", tc.bits2))
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			checkHalfPrecisionSum(t, h, tc.bits1, tc.bits2)
+		})
+	}
+}
+
+func TestHalfPrecisionAdder_Random(t *testing.T) {
+	bus1, _ := NewSwitchBus(16, 0)
+	bus2, _ := NewSwitchBus(16, 0)
+
+	h, err := NewHalfPrecisionAdderFromBuses(bus1, bus2)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	r := rand.New(rand.NewSource(754))
+
+	for i := 0; i < 300; i++ {
+		a, b := uint16(r.Intn(1<<16)), uint16(r.Intn(1<<16))
+		if i%2 == 0 {
+			b = b&0x83FF | a&0x7C00 // exponents close together, so more cancelling and carrying
+		}
+
+		Simultaneously(func() {
+			bus1.SetUint(uint64(a))
+			bus2.SetUint(uint64(b))
+		})
+
+		checkHalfPrecisionSum(t, h, a, b)
+	}
+}
+
+// checkHalfPrecisionSum compares the adder against Go, which adds exactly in float64 and rounds just once on the way back to half precision
+func checkHalfPrecisionSum(t *testing.T, h *HalfPrecisionAdder, a, b uint16) {
+	sum := Float16ToFloat64(a) + Float16ToFloat64(b)
+	want := Float64ToFloat16(sum)
+
+	got := uint16(h.Sum().Uint())
+
+	if math.IsNaN(sum) {
+		if !math.IsNaN(h.Float()) {
+			t.Errorf("Wanted %04X + %04X to be NaN, but got %04X", a, b, got)
+		}
+		return
+	}
+
+	if got != want {
+		t.Errorf("Wanted %04X + %04X (%v + %v) to be %04X (%v), but got %04X (%v)", a, b, Float16ToFloat64(a), Float16ToFloat64(b), want, sum, got, h.Float())
+	}
+}
+
+// Fragile test due to timing of asking oscillator vs. state of oscillator at the time being asked
+func TestOscillator(t *testing.T) {
+	testCases := []struct {
+		initState     bool
+		oscHertz      int
+		checkTimes    int
+		wantAllTrues  bool
+		wantAllFalses bool
+		wantTrueFalse bool
+	}{
+		{false, 10, 1, false, true, false},
+		{true, 10, 1, true, false, false},
+		{false, 40, 5, false, false, true},
+		{true, 40, 10, false, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Oscillating at %d hertz, immediate start (%t), checking %d times.", tc.oscHertz, tc.initState, tc.checkTimes), func(t *testing.T) {
+
+			var results string
+
+			o := newOscillator(tc.initState)
+			o.Oscillate(tc.oscHertz)
+
+			for i := 0; i < tc.checkTimes; i++ {
+				if o.Emitting() {
+					results += "T"
+				} else {
+					results += "F"
+				}
+				time.Sleep(time.Millisecond*500 + time.Second/time.Duration(tc.oscHertz*2)) // half a tick off, so checks don't line up with the ticks
+			}
+			o.Stop()
+
+			gotAllTrues := !strings.Contains(results, "F")
+			gotAllFalses := !strings.Contains(results, "T")
+
+			if (gotAllTrues != tc.wantAllTrues) || (gotAllFalses != tc.wantAllFalses) {
+				t.Errorf(fmt.Sprintf("Wanted all trues (%t), all falses (%t), and mixed (%t), but got results of %s.", tc.wantAllTrues, tc.wantAllFalses, tc.wantTrueFalse, results))
+			}
+		})
+	}
+}
+
+func TestOscillator_WiredSubscriber(t *testing.T) {
+	// the oscillator ticks on its own goroutine, but the inverter only ever changes on this one (go test -race keeps this honest)
+	o := newOscillator(false)
+	inv := newInverter(o)
+
+	o.Oscillate(200)
+	defer o.Stop()
+
+	for i := 0; i < 10; i++ {
+		sig := o.AwaitTick()
+
+		if got, want := inv.Signal(), map[Signal]Signal{High: Low, Low: High}[sig]; got != want {
+			t.Errorf("Wanted the inverter at %s after the oscillator went %s, but got %s", want, sig, got)
+		}
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !o.Deliver() {
+		t.Error("Wanted a tick waiting to be delivered")
+	}
+	if inv.Signal() == o.Delivered() {
+		t.Error("Wanted the inverter to follow the delivered tick")
+	}
+}
+
+func TestRSFlipFlop_Construction(t *testing.T) {
+	testCases := []struct {
+		rPin      emitter
+		sPin      emitter
+		wantError string
+	}{
+		{nil, nil, ""},
+		{nil, &Battery{}, ""},
+		{&Battery{}, nil, ""},
+		{&Battery{}, &Battery{}, "Both inputs of a Flip-Flop cannot be powered simultaneously"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Creating as rIn (%T) and sIn (%T)", tc.rPin, tc.sPin), func(t *testing.T) {
 			_, err := newRSFlipFLop(tc.rPin, tc.sPin)
 
-			if err != nil && err.Error() != tc.wantError {
-				t.Errorf(fmt.Sprintf("Wanted error %s but got %s.", tc.wantError, err.Error()))
+			if err != nil && err.Error() != tc.wantError {
+				t.Errorf(fmt.Sprintf("Wanted error %s but got %s.", tc.wantError, err.Error()))
+			}
+		})
+	}
+}
+
+func TestRSFlipFlop_ValidateInputs(t *testing.T) {
+	testCases := []struct {
+		rPin      emitter
+		sPin      emitter
+		wantError string
+	}{
+		{nil, nil, ""},
+		{nil, &Battery{}, ""},
+		{&Battery{}, nil, ""},
+		{&Battery{}, &Battery{}, "Both inputs of a Flip-Flop cannot be powered simultaneously"},
+	}
+
+	// starting with no input signals
+	f, err := newRSFlipFLop(nil, nil)
+
+	if err != nil {
+		t.Error(fmt.Sprintf("Expecting no errors on initial creation but got %s.", err))
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Setting up as rIn (%T) and sIn (%T)", tc.rPin, tc.sPin), func(t *testing.T) {
+			err := f.updateInputs(tc.rPin, tc.sPin)
+
+			if err != nil && err.Error() != tc.wantError {
+				t.Errorf(fmt.Sprintf("Wanted error %s but got %s.", tc.wantError, err.Error()))
+			}
+		})
+	}
+}
+
+// BROKEN TEST
+func TestRSFlipFlop_qEmitting_InputValidation(t *testing.T) {
+	testCases := []struct {
+		rPin      emitter
+		sPin      emitter
+		wantError string
+	}{
+		//{nil, nil, ""},
+		//{nil, &Battery{}, ""},
+		//{&Battery{}, nil, ""},
+		{&Battery{}, &Battery{}, "Both inputs of a Flip-Flop cannot be powered simultaneously"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Creating as rIn (%T) and sIn (%T)", tc.rPin, tc.sPin), func(t *testing.T) {
+			var rPin emitter = nil
+			var sPin emitter = nil
+
+			// starting with no input signals
+			f, err := newRSFlipFLop(rPin, sPin)
+
+			if err != nil {
+				t.Error(fmt.Sprintf("Expecting no errors on initial creation but got %s.", err))
+			}
+
+			// this doesn't seem to actually change the flip-flop's inner pins (hmmmm)
+			// this doesn't seem to actually change the flip-flop's inner pins (hmmmm)
+			// this doesn't seem to actually change the flip-flop's inner pins (hmmmm)
+			// this doesn't seem to actually change the flip-flop's inner pins (hmmmm)
+			rPin = tc.rPin
+			sPin = tc.sPin
+
+			_, err = f.qEmitting()
+
+			if err != nil && err.Error() != tc.wantError {
+				t.Errorf(fmt.Sprintf("Wanted error %t on Q, but got %t.", tc.wantError, err))
+			}
+		})
+	}
+}
+
+func TestRSFlipFlop(t *testing.T) {
+	testCases := []struct {
+		rPin      emitter
+		sPin      emitter
+		wantQ     bool
+		wantQBar  bool
+		wantError string
+	}{
+		{nil, nil, false, false, ""}, // never set or reset, so Q and QBar are both X
+		{nil, &Battery{}, true, false, ""},
+		{nil, nil, true, false, ""},
+		{&Battery{}, nil, false, true, ""},
+		{nil, nil, false, true, ""},
+		{nil, &Battery{}, true, false, ""},
+		{&Battery{}, &Battery{}, true, false, "Both inputs of a Flip-Flop cannot be powered simultaneously"},
+	}
+
+	testName := func(i int) string {
+		var priorR emitter
+		var priorS emitter
+
+		if i == 0 {
+			priorR = nil
+			priorS = nil
+		} else {
+			priorR = testCases[i-1].rPin
+			priorS = testCases[i-1].sPin
+		}
+
+		return fmt.Sprintf("Stage %d: Switching from [rIn (%T) sIn (%T)] to [rIn (%T) sIn (%T)]", i+1, priorR, priorS, testCases[i].rPin, testCases[i].sPin)
+	}
+
+	// starting with no input signals
+	f, err := newRSFlipFLop(nil, nil)
+
+	if err != nil {
+		t.Error(fmt.Sprintf("Expecting no errors on initial creation but got %s.", err))
+	}
+
+	for i, tc := range testCases {
+		t.Run(testName(i), func(t *testing.T) {
+			err := f.updateInputs(tc.rPin, tc.sPin)
+
+			if err != nil && err.Error() != tc.wantError {
+				t.Error(fmt.Sprintf("Wanted error %s but got %s.", tc.wantError, err))
+			}
+
+			if gotQ, _ := f.qEmitting(); gotQ != tc.wantQ {
+				t.Errorf(fmt.Sprintf("Wanted power of %t on Q, but got %t.", tc.wantQ, gotQ))
+			}
+
+			if gotQBar, _ := f.qBarEmitting(); gotQBar != tc.wantQBar {
+				t.Errorf(fmt.Sprintf("Wanted power of %t on QBar, but got %t.", tc.wantQBar, gotQBar))
+			}
+		})
+	}
+}
+
+func TestRSFlipFlop_qSignal(t *testing.T) {
+	testCases := []struct {
+		rIn       bool
+		sIn       bool
+		wantQ     Signal
+		wantQBar  Signal
+		wantError bool
+	}{
+		{false, false, X, X, false}, // never set or reset, so unknown
+		{false, true, High, Low, false},
+		{false, false, High, Low, false},
+		{true, false, Low, High, false},
+		{true, true, X, X, true},
+		{false, false, X, X, false}, // releasing both inputs at the same instant leaves the NORs racing forever
+		{true, false, Low, High, false},
+	}
+
+	rSwitch := NewSwitch(false)
+	sSwitch := NewSwitch(false)
+
+	f, err := newRSFlipFLop(rSwitch, sSwitch)
+
+	if err != nil {
+		t.Error(fmt.Sprintf("Expecting no errors on initial creation but got %s.", err))
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Stage %d: Switching to rIn (%t) sIn (%t)", i+1, tc.rIn, tc.sIn), func(t *testing.T) {
+			Simultaneously(func() {
+				rSwitch.Set(tc.rIn)
+				sSwitch.Set(tc.sIn)
+			})
+
+			gotQ, err := f.qSignal()
+			if (err != nil) != tc.wantError {
+				t.Errorf("Wanted error (%t), but got %v.", tc.wantError, err)
+			}
+			if gotQ != tc.wantQ {
+				t.Errorf("Wanted signal %s on Q, but got %s.", tc.wantQ, gotQ)
+			}
+
+			if gotQBar, _ := f.qBarSignal(); gotQBar != tc.wantQBar {
+				t.Errorf("Wanted signal %s on QBar, but got %s.", tc.wantQBar, gotQBar)
+			}
+		})
+	}
+}
+
+func TestRSFlipFlop_NANDLatch(t *testing.T) {
+	// active-low inputs, so holding is both Switches on
+	testCases := []struct {
+		sBar     bool
+		rBar     bool
+		wantQ    Signal
+		wantQBar Signal
+	}{
+		{true, true, X, X},
+		{false, true, High, Low},
+		{true, true, High, Low},
+		{true, false, Low, High},
+		{true, true, Low, High},
+	}
+
+	sBarSwitch := NewSwitch(true)
+	rBarSwitch := NewSwitch(true)
+
+	loop := newJumper(X)
+	q := newNANDGate(sBarSwitch, loop)
+	qBar := newNANDGate(rBarSwitch, q)
+	loop.connect(qBar)
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Stage %d: Switching to sBar (%t) rBar (%t)", i+1, tc.sBar, tc.rBar), func(t *testing.T) {
+			sBarSwitch.Set(tc.sBar)
+			rBarSwitch.Set(tc.rBar)
+
+			if got := q.Signal(); got != tc.wantQ {
+				t.Errorf("Wanted signal %s on Q, but got %s.", tc.wantQ, got)
+			}
+			if got := qBar.Signal(); got != tc.wantQBar {
+				t.Errorf("Wanted signal %s on QBar, but got %s.", tc.wantQBar, got)
+			}
+			if loop.unsettled {
+				t.Error("Wanted the latch to settle, but it never did.")
+			}
+		})
+	}
+}
+
+func TestUnsettledLoops(t *testing.T) {
+	rSwitch := NewSwitch(true)
+	sSwitch := NewSwitch(false)
+
+	f, _ := newRSFlipFLop(rSwitch, sSwitch)
+
+	if got := UnsettledLoops(f); len(got) != 0 {
+		t.Errorf("Wanted no unsettled loops after a reset, but got %v.", got)
+	}
+
+	sSwitch.Set(true)
+	Simultaneously(func() {
+		rSwitch.Set(false)
+		sSwitch.Set(false)
+	})
+
+	want := []string{"rLoop", "sLoop"}
+	if got := UnsettledLoops(f); !reflect.DeepEqual(got, want) {
+		t.Errorf("Wanted unsettled loops %v, but got %v.", want, got)
+	}
+
+	sSwitch.Set(true)
+
+	if got := UnsettledLoops(f); len(got) != 0 {
+		t.Errorf("Wanted no unsettled loops after a set, but got %v.", got)
+	}
+	if q, _ := f.qSignal(); q != High {
+		t.Errorf("Wanted signal %s on Q after a set, but got %s.", High, q)
+	}
+}
+
+func TestJumper_RingOscillator(t *testing.T) {
+	for _, inverters := range []int{1, 3, 5} {
+		t.Run(fmt.Sprintf("%d inverters", inverters), func(t *testing.T) {
+			loop := newJumper(Low)
+
+			var last emitter = loop
+			for i := 0; i < inverters; i++ {
+				last = newInverter(last)
+			}
+			loop.connect(last)
+
+			if !loop.unsettled {
+				t.Error("Wanted the ring to never settle, but it did.")
+			}
+			if got := last.Signal(); got != X {
+				t.Errorf("Wanted signal %s out of the ring, but got %s.", X, got)
+			}
+		})
+	}
+}
+
+func TestJumper_Reconnect(t *testing.T) {
+	oldSwitch := NewSwitch(true)
+	newSwitch := NewSwitch(false)
+
+	j := newJumper(X)
+	j.connect(oldSwitch)
+
+	if got := j.Signal(); got != High {
+		t.Errorf("Wanted signal %s from the first source, but got %s.", High, got)
+	}
+
+	j.connect(newSwitch)
+	oldSwitch.Set(true)
+	oldSwitch.Set(false)
+
+	if got := j.Signal(); got != Low {
+		t.Errorf("Wanted signal %s from the new source only, but got %s.", Low, got)
+	}
+
+	newSwitch.Set(true)
+
+	if got := j.Signal(); got != High {
+		t.Errorf("Wanted signal %s from the new source, but got %s.", High, got)
+	}
+}
+
+func TestLevTrigDLatch(t *testing.T) {
+	testCases := []struct {
+		dataPin   emitter
+		clkPin    emitter
+		wantQ     bool
+		wantQBar  bool
+		wantError string
+	}{
+		{nil, nil, false, false, ""}, // never latched, so Q and QBar are both X
+		{&Battery{}, nil, false, false, ""},
+		{&Battery{}, &Battery{}, true, false, ""},
+		{&Battery{}, nil, true, false, ""},
+		{nil, nil, true, false, ""},
+		{nil, &Battery{}, false, true, ""},
+		{nil, nil, false, true, ""},
+		{&Battery{}, &Battery{}, true, false, ""},
+	}
+
+	testName := func(i int) string {
+		var priorD emitter
+		var priorC emitter
+
+		if i == 0 {
+			priorD = nil
+			priorC = nil
+		} else {
+			priorD = testCases[i-1].dataPin
+			priorC = testCases[i-1].clkPin
+		}
+
+		return fmt.Sprintf("Stage %d: Switching from [dataIn (%T) clkIn (%T)] to [dataIn (%T) clkIn (%T)]", i+1, priorD, priorC, testCases[i].dataPin, testCases[i].clkPin)
+	}
+
+	// starting with no input signals
+	l, err := newLtDLatch(nil, nil)
+
+	if err != nil {
+		t.Error(fmt.Sprintf("Expecting no errors on initial creation but got %s.", err))
+	}
+
+	for i, tc := range testCases {
+		t.Run(testName(i), func(t *testing.T) {
+			l.updateInputs(tc.dataPin, tc.clkPin)
+
+			if gotQ, _ := l.qEmitting(); gotQ != tc.wantQ {
+				t.Errorf(fmt.Sprintf("Wanted power of %t on Q, but got %t.", tc.wantQ, gotQ))
+			}
+
+			if gotQBar, _ := l.qBarEmitting(); gotQBar != tc.wantQBar {
+				t.Errorf(fmt.Sprintf("Wanted power of %t on QBar, but got %t.", tc.wantQBar, gotQBar))
+			}
+		})
+	}
+}
+
+func TestLevTrigDLatch_DrivenBySwitches(t *testing.T) {
+	testCases := []struct {
+		dataIn   bool
+		clkIn    bool
+		wantQ    bool
+		wantQBar bool
+	}{
+		{false, false, false, false}, // never latched, so Q and QBar are both X
+		{true, false, false, false},
+		{true, true, true, false},
+		{true, false, true, false},
+		{false, false, true, false},
+		{false, true, false, true},
+		{false, false, false, true},
+	}
+
+	dataSwitch := NewSwitch(false)
+	clkSwitch := NewSwitch(false)
+
+	l, err := newLtDLatch(dataSwitch, clkSwitch)
+
+	if err != nil {
+		t.Error(fmt.Sprintf("Expecting no errors on initial creation but got %s.", err))
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Stage %d: Switching to dataIn (%t) clkIn (%t)", i+1, tc.dataIn, tc.clkIn), func(t *testing.T) {
+			dataSwitch.Set(tc.dataIn)
+			clkSwitch.Set(tc.clkIn)
+
+			if gotQ, _ := l.qEmitting(); gotQ != tc.wantQ {
+				t.Errorf("Wanted power of %t on Q, but got %t.", tc.wantQ, gotQ)
+			}
+
+			if gotQBar, _ := l.qBarEmitting(); gotQBar != tc.wantQBar {
+				t.Errorf("Wanted power of %t on QBar, but got %t.", tc.wantQBar, gotQBar)
+			}
+		})
+	}
+}
+
+func TestSimulation_RelayDelay(t *testing.T) {
+	testCases := []struct {
+		delay       time.Duration
+		flipAt      time.Duration
+		wantSettled time.Duration
+	}{
+		{time.Millisecond * 10, 0, time.Millisecond * 10},
+		{time.Millisecond * 10, time.Millisecond * 5, time.Millisecond * 15},
+		{time.Millisecond * 25, time.Millisecond * 5, time.Millisecond * 30},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Flipping at %v with a relay delay of %v", tc.flipAt, tc.delay), func(t *testing.T) {
+			sw := NewSwitch(false)
+			r := newRelay(&Battery{}, sw)
+			r.setDelay(tc.delay)
+
+			sim := NewSimulation()
+			sim.After(tc.flipAt, func() { sw.Set(true) })
+
+			settled, err := sim.Run(time.Second)
+			if err != nil {
+				t.Error("Unexpected error: " + err.Error())
+			}
+
+			if settled != tc.wantSettled {
+				t.Errorf("Wanted relay to settle at %v, but got %v", tc.wantSettled, settled)
+			}
+
+			if !r.closedOut.Emitting() || r.openOut.Emitting() {
+				t.Error("Wanted the relay to have switched to its closed contact, but it did not")
+			}
+		})
+	}
+}
+
+func TestSimulation_EightBitAdderRipple(t *testing.T) {
+	testCases := []struct {
+		byte1      string
+		byte2      string
+		wantAnswer string
+		wantSettle time.Duration // a carry that ripples through every full adder takes far longer to settle than one that stops at the first
+	}{
+		{"00000000", "00000000", "00000001", time.Millisecond * 30},
+		{"00000001", "00000000", "00000010", time.Millisecond * 50},
+		{"11111111", "00000000", "100000000", time.Millisecond * 160},
+		{"01010101", "00101010", "10000000", time.Millisecond * 170},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Flipping carry in on for %s plus %s", tc.byte1, tc.byte2), func(t *testing.T) {
+			carryIn := NewSwitch(false)
+
+			a, err := NewEightBitAdder(tc.byte1, tc.byte2, carryIn)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			sim := NewSimulation()
+			sim.After(0, func() { carryIn.Set(true) })
+
+			settled, err := sim.Run(time.Second)
+			if err != nil {
+				t.Error("Unexpected error: " + err.Error())
+			}
+
+			if got := a.String(); got != tc.wantAnswer {
+				t.Errorf("Wanted answer %s, but got %s", tc.wantAnswer, got)
+			}
+
+			if settled != tc.wantSettle {
+				t.Errorf("Wanted the adder to settle at %v, but got %v", tc.wantSettle, settled)
+			}
+		})
+	}
+}
+
+func TestSimulation_RingOscillator(t *testing.T) {
+	in := NewSwitch(false)
+	inv := newInverter(in)
+
+	sim := NewSimulation()
+	sim.Watch("inverter", inv)
+
+	// wiring the inverter's output back to its own input only makes sense with relay delay, otherwise it would flip forever in an instant
+	sim.After(0, func() {
+		inv.WireUp(func(sig Signal) { in.Set(sig == High) })
+	})
+
+	if _, err := sim.Run(time.Millisecond * 100); err == nil {
+		t.Error("Expected the ring oscillator to never settle, but it did")
+	}
+
+	transitions := sim.Transitions()
+
+	if len(transitions) != 10 {
+		t.Errorf("Wanted 10 transitions in 100ms, but got %d", len(transitions))
+	}
+
+	for i, tr := range transitions {
+		if want := DefaultRelayDelay * time.Duration(i+1); tr.At != want {
+			t.Errorf("Wanted transition %d at %v, but got %v", i+1, want, tr.At)
+		}
+		if want := boolSignal(i%2 == 1); tr.Signal != want {
+			t.Errorf("Wanted transition %d to %s, but got %s", i+1, want, tr.Signal)
+		}
+	}
+}
+
+func TestFaultDiffs_EightBitAdder(t *testing.T) {
+	testCases := []struct {
+		byte1     string
+		byte2     string
+		fault     Fault
+		wantDiffs string
+	}{
+		{"00000001", "00000001", Fault{StuckAt1, "fullAdders[7].carry"}, ""}, // already carrying, so no difference
+		{"00000001", "00000000", Fault{StuckAt1, "fullAdders[7].carry"}, "fullAdders[6].sum:0>1"},
+		{"00000001", "00000001", Fault{StuckAt0, "fullAdders[7].carry"}, "fullAdders[6].sum:1>0"},
+		{"11111111", "00000001", Fault{StuckAt0, "fullAdders[5].carry"}, "carryOut:1>0,fullAdders[0].sum:0>1,fullAdders[1].sum:0>1,fullAdders[2].sum:0>1,fullAdders[3].sum:0>1,fullAdders[4].sum:0>1"},
+		{"00000000", "00000000", Fault{StuckAt1, "byte1Switches[0]"}, "fullAdders[0].sum:0>1"},
+		{"00000000", "00000000", Fault{StuckAt1, "fullAdders[2].halfAdder1.sum"}, "fullAdders[2].sum:0>1"},
+		{"00000001", "00000000", Fault{WeldedContact, "fullAdders[7].halfAdder1.carry.relay2"}, "fullAdders[6].sum:0>1"}, // carry passes the first input straight through
+		{"00000001", "00000001", Fault{OpenContact, "fullAdders[7].halfAdder1.carry.relay2"}, "fullAdders[6].sum:1>0"},
+		{"00000000", "00000000", Fault{OpenContact, "fullAdders[7].halfAdder1.carry.relay2"}, ""},
+		{"00000000", "00000000", Fault{WeldedContact, "fullAdders[4].halfAdder1.sum.nandGate.relay1"}, ""}, // the NAND's other relay still holds it high
+	}
+
+	diffsToString := func(diffs []FaultDiff) string {
+		var strs []string
+		for _, d := range diffs {
+			strs = append(strs, fmt.Sprintf("%s:%s>%s", d.Output, d.Want, d.Got))
+		}
+		return strings.Join(strs, ",")
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Adding %s to %s with a %s fault at %s", tc.byte1, tc.byte2, tc.fault.Kind, tc.fault.Path), func(t *testing.T) {
+			a, err := NewEightBitAdder(tc.byte1, tc.byte2, nil)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			faultFree := a.String()
+
+			diffs, err := FaultDiffs(a, tc.fault)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
+			}
+
+			if got := diffsToString(diffs); got != tc.wantDiffs {
+				t.Errorf("Wanted differences %q, but got %q", tc.wantDiffs, got)
+			}
+
+			if got := a.String(); got != faultFree {
+				t.Errorf("Wanted the adder repaired back to %s, but got %s", faultFree, got)
+			}
+		})
+	}
+}
+
+func TestFaultDiffs_EightBitSubtractor(t *testing.T) {
+	s, err := NewEightBitSubtractor("00000011", "00000001")
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	diffs, err := FaultDiffs(s, Fault{StuckAt0, "adder.fullAdders[7].carry"})
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	// 3 - 1 is really 00000011 + 11111110 + 1, so losing the lowest carry only loses the 1 that the second bit would have summed to
+	if len(diffs) != 1 || diffs[0] != (FaultDiff{"adder.fullAdders[6].sum", High, Low}) {
+		t.Errorf("Wanted only the second bit of the answer to differ, but got %v", diffs)
+	}
+}
+
+func TestInjectFault_BadFaults(t *testing.T) {
+	testCases := []struct {
+		fault     Fault
+		wantError string
+	}{
+		{Fault{StuckAt0, "fullAdders[8].sum"}, "No part named fullAdders[8] in path:"},
+		{Fault{StuckAt0, "fullAdders[0].sum.bogus"}, "No part named bogus in path:"},
+		{Fault{StuckAt0, "fullAdders[0].halfAdder1.carry.relay1.openOut.bogus"}, "Cannot find bogus inside a part with no parts of its own:"},
+		{Fault{StuckAt1, "fullAdders[0]"}, "Cannot inject a stuck-at-1 fault into a part that is not a wire:"},
+		{Fault{WeldedContact, "fullAdders[0].sum"}, "Cannot inject a welded contact fault into a part that is not a relay:"},
+		{Fault{OpenContact, "fullAdders[0].halfAdder1"}, "Cannot inject a open contact fault into a part that is not a relay:"},
+	}
+
+	a, err := NewEightBitAdder("00000000", "00000000", nil)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Injecting a %s fault at %s", tc.fault.Kind, tc.fault.Path), func(t *testing.T) {
+			repair, err := InjectFault(a, tc.fault)
+
+			if err == nil || !strings.HasPrefix(err.Error(), tc.wantError) {
+				t.Errorf("Wanted error %q, but got %v", tc.wantError, err)
+			}
+
+			if repair != nil {
+				t.Error("Did not expect a repair func to return due to a bad fault, but got one.")
+			}
+		})
+	}
+}
+
+func TestTakeCensus(t *testing.T) {
+	eightBitAdder, _ := NewEightBitAdder("00000000", "00000000", nil)
+	sixteenBitAdder, _ := NewSixteenBitAdder("0000000000000000", "0000000000000000", nil)
+	latch, _ := newLtDLatch(nil, nil)
+
+	testCases := []struct {
+		name             string
+		circuit          assembly
+		want             Census
+		wantRelayEquivts int
+	}{
+		{"relay", newRelay(&Battery{}, nil), Census{1, 0, 1, 1, 1, 0, map[string]int{}}, 1},
+		{"inverter", newInverter(nil), Census{0, 1, 0, 1, 1, 0, map[string]int{}}, 1},
+		{"XNOR gate", newXNORGate(nil, nil), Census{6, 1, 6, 7, 6, 0, map[string]int{"AND": 1, "OR": 1, "NAND": 1, "XOR": 1, "XNOR (inverter)": 1}}, 7},
+		{"half adder", newHalfAdder(nil, nil), Census{8, 0, 8, 8, 6, 0, map[string]int{"AND": 2, "OR": 1, "NAND": 1, "XOR": 1}}, 8},
+		{"full adder", newFullAdder(nil, nil, nil), Census{18, 0, 18, 18, 14, 0, map[string]int{"AND": 4, "OR": 3, "NAND": 2, "XOR": 2}}, 18},
+		{"8-bit adder", eightBitAdder, Census{144, 0, 144, 144, 112, 16, map[string]int{"AND": 32, "OR": 24, "NAND": 16, "XOR": 16}}, 144},
+		{"16-bit adder", sixteenBitAdder, Census{288, 0, 288, 288, 224, 32, map[string]int{"AND": 64, "OR": 48, "NAND": 32, "XOR": 32}}, 288},
+		{"level-triggered D latch", latch, Census{8, 1, 8, 9, 5, 0, map[string]int{"AND": 2, "NOR": 2}}, 9},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Taking census of a %s", tc.name), func(t *testing.T) {
+			got := TakeCensus(tc.circuit)
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Wanted census:\n%s\nbut got:\n%s", tc.want, got)
+			}
+
+			if got.RelayEquivalents() != tc.wantRelayEquivts {
+				t.Errorf("Wanted %d relay equivalents, but got %d", tc.wantRelayEquivts, got.RelayEquivalents())
+			}
+		})
+	}
+}
+
+func TestBus(t *testing.T) {
+	testCases := []struct {
+		width      int
+		value      uint64
+		wantString string
+		wantError  string
+	}{
+		{1, 0, "0", ""},
+		{1, 1, "1", ""},
+		{1, 2, "", "Value 2 does not fit in a 1-bit Bus"},
+		{8, 0x5A, "01011010", ""},
+		{8, 0xFF, "11111111", ""},
+		{8, 0x100, "", "Value 256 does not fit in a 8-bit Bus"},
+		{16, 0xBEEF, "1011111011101111", ""},
+		{64, 1<<63 + 1, "1" + strings.Repeat("0", 62) + "1", ""},
+		{0, 0, "", "Bus width must be at least 1, but got 0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Setting a %d-bit Bus to %d", tc.width, tc.value), func(t *testing.T) {
+			b, err := NewSwitchBus(tc.width, tc.value)
+
+			if err != nil {
+				if err.Error() != tc.wantError {
+					t.Errorf("Wanted error %q, but got %q", tc.wantError, err.Error())
+				}
+				return
+			}
+
+			if got := b.String(); got != tc.wantString {
+				t.Errorf("Wanted bits %s, but got %s", tc.wantString, got)
+			}
+
+			if got := b.Uint(); got != tc.value {
+				t.Errorf("Wanted value %d, but got %d", tc.value, got)
+			}
+
+			if got := b.Width(); got != tc.width {
+				t.Errorf("Wanted width %d, but got %d", tc.width, got)
 			}
 		})
 	}
 }
 
-func TestRSFlipFlop_ValidateInputs(t *testing.T) {
+func TestBus_SliceAndConcat(t *testing.T) {
+	b, _ := NewSwitchBus(16, 0xBE0F)
+
+	high := b.Slice(0, 8)
+	low := b.Slice(8, 16)
+
+	if high.Uint() != 0xBE || low.Uint() != 0x0F {
+		t.Errorf("Wanted slices of BE and 0F, but got %X and %X", high.Uint(), low.Uint())
+	}
+
+	swapped := low.Concat(high)
+	if swapped.Uint() != 0x0FBE {
+		t.Errorf("Wanted concatenation of 0FBE, but got %X", swapped.Uint())
+	}
+
+	// the slices share the same wires, so setting one shows through everywhere
+	if err := low.SetUint(0xAA); err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	if b.Uint() != 0xBEAA || swapped.Uint() != 0xAABE {
+		t.Errorf("Wanted BEAA and AABE after setting the low slice, but got %X and %X", b.Uint(), swapped.Uint())
+	}
+
+	if bits := NewBus(nil, &Battery{}).Bits(); !reflect.DeepEqual(bits, []Signal{Low, High}) {
+		t.Errorf("Wanted bits [0 1], but got %v", bits)
+	}
+
+	if err := NewBus(&Battery{}).SetUint(0); err == nil {
+		t.Error("Expected an error setting a Bus that isn't made of switches, but got none")
+	}
+}
+
+func TestBus_WiringCircuits(t *testing.T) {
 	testCases := []struct {
-		rPin      emitter
-		sPin      emitter
-		wantError string
+		a       uint64
+		b       uint64
+		wantSum uint64
+		wantNeg uint64
 	}{
-		{nil, nil, ""},
-		{nil, &Battery{}, ""},
-		{&Battery{}, nil, ""},
-		{&Battery{}, &Battery{}, "Both inputs of a Flip-Flop cannot be powered simultaneously"},
+		{0, 0, 0, 0},
+		{1, 2, 3, 0xFD},
+		{100, 27, 127, 0x81},
+		{200, 100, 44, 0xD4}, // sum overflows 8 bits
+		{255, 1, 0, 0},
 	}
 
-	// starting with no input signals
-	f, err := newRSFlipFLop(nil, nil)
+	aBus, _ := NewSwitchBus(8, 0)
+	bBus, _ := NewSwitchBus(8, 0)
 
+	// a + b, then complemented and added to zero with a carry in to negate it (two's complement), never touching a string along the way
+	sum, err := NewEightBitAdderFromBuses(aBus, bBus, nil)
 	if err != nil {
-		t.Error(fmt.Sprintf("Expecting no errors on initial creation but got %s.", err))
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	comp := NewOnesComplementerFromBus(sum.Sum(), &Battery{})
+
+	zero, _ := NewSwitchBus(8, 0)
+	neg, err := NewEightBitAdderFromBuses(comp.ComplementBus(), zero, &Battery{})
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
 	}
 
 	for _, tc := range testCases {
-		t.Run(fmt.Sprintf("Setting up as rIn (%T) and sIn (%T)", tc.rPin, tc.sPin), func(t *testing.T) {
-			err := f.updateInputs(tc.rPin, tc.sPin)
+		t.Run(fmt.Sprintf("Adding %d to %d then negating", tc.a, tc.b), func(t *testing.T) {
+			aBus.SetUint(tc.a)
+			bBus.SetUint(tc.b)
 
-			if err != nil && err.Error() != tc.wantError {
-				t.Errorf(fmt.Sprintf("Wanted error %s but got %s.", tc.wantError, err.Error()))
+			if got := sum.Sum().Uint(); got != tc.wantSum {
+				t.Errorf("Wanted sum %d, but got %d", tc.wantSum, got)
+			}
+
+			if got := neg.Sum().Uint(); got != tc.wantNeg {
+				t.Errorf("Wanted negated sum %X, but got %X", tc.wantNeg, got)
 			}
 		})
 	}
+
+	if err := sum.UpdateInputs("00000000", "00000000"); err == nil {
+		t.Error("Expected an error updating the inputs of an adder built from Buses, but got none")
+	}
 }
 
-// BROKEN TEST
-func TestRSFlipFlop_qEmitting_InputValidation(t *testing.T) {
+func TestSixteenBitAdderFromBuses(t *testing.T) {
+	aBus, _ := NewSwitchBus(16, 0x9D9D)
+	bBus, _ := NewSwitchBus(16, 0xD6D6)
+
+	a, err := NewSixteenBitAdderFromBuses(aBus, bBus, nil)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	if got := a.Sum().Uint(); got != 0x7473 || !a.CarryOut().Emitting() {
+		t.Errorf("Wanted sum 7473 with a carry, but got %X (carry %t)", got, a.CarryOut().Emitting())
+	}
+
+	if _, err := NewSixteenBitAdderFromBuses(aBus.Slice(0, 8), bBus, nil); err == nil {
+		t.Error("Expected an error building from an 8-bit Bus, but got none")
+	}
+}
+
+func TestAdder_Widths(t *testing.T) {
 	testCases := []struct {
-		rPin      emitter
-		sPin      emitter
+		width     int
+		bits1     string
+		bits2     string
+		carryIn   emitter
+		wantSum   string
 		wantError string
 	}{
-		//{nil, nil, ""},
-		//{nil, &Battery{}, ""},
-		//{&Battery{}, nil, ""},
-		{&Battery{}, &Battery{}, "Both inputs of a Flip-Flop cannot be powered simultaneously"},
+		{1, "1", "1", nil, "10", ""},
+		{1, "0", "0", &Battery{}, "1", ""},
+		{4, "1001", "1011", nil, "10100", ""},
+		{4, "0111", "0001", &Battery{}, "1001", ""},
+		{32, strings.Repeat("01", 16), strings.Repeat("10", 16), &Battery{}, "1" + strings.Repeat("0", 32), ""},
+		{64, strings.Repeat("1", 64), strings.Repeat("0", 63) + "1", nil, "1" + strings.Repeat("0", 64), ""},
+		{128, strings.Repeat("0", 127) + "1", strings.Repeat("0", 126) + "11", nil, strings.Repeat("0", 125) + "100", ""},
+		{4, "100", "1011", nil, "", "First input not in 4-bit binary format: 100"},
+		{32, strings.Repeat("0", 32), "1", nil, "", "Second input not in 32-bit binary format: 1"},
+		{0, "", "", nil, "", "Adder width must be at least 1, but got 0"},
 	}
 
 	for _, tc := range testCases {
-		t.Run(fmt.Sprintf("Creating as rIn (%T) and sIn (%T)", tc.rPin, tc.sPin), func(t *testing.T) {
-			var rPin emitter = nil
-			var sPin emitter = nil
+		t.Run(fmt.Sprintf("Adding %d-bit %s to %s with carry in of %T", tc.width, tc.bits1, tc.bits2, tc.carryIn), func(t *testing.T) {
+			a, err := NewAdder(tc.width, tc.bits1, tc.bits2, tc.carryIn)
 
-			// starting with no input signals
-			f, err := newRSFlipFLop(rPin, sPin)
+			if tc.wantError != "" {
+				if err == nil || err.Error() != tc.wantError {
+					t.Errorf("Wanted error %q, but got %v.", tc.wantError, err)
+				}
+				return
+			}
 
 			if err != nil {
-				t.Error(fmt.Sprintf("Expecting no errors on initial creation but got %s.", err))
+				t.Fatal("Unexpected error: " + err.Error())
 			}
-
-			// this doesn't seem to actually change the flip-flop's inner pins (hmmmm)
-			// this doesn't seem to actually change the flip-flop's inner pins (hmmmm)
-			// this doesn't seem to actually change the flip-flop's inner pins (hmmmm)
-			// this doesn't seem to actually change the flip-flop's inner pins (hmmmm)
-			rPin = tc.rPin
-			sPin = tc.sPin
-
-			_, err = f.qEmitting()
-
-			if err != nil && err.Error() != tc.wantError {
-				t.Errorf(fmt.Sprintf("Wanted error %t on Q, but got %t.", tc.wantError, err))
+			if a.Width() != tc.width {
+				t.Errorf("Wanted width %d, but got %d", tc.width, a.Width())
+			}
+			if got := a.String(); got != tc.wantSum {
+				t.Errorf("Wanted answer %s, but got %s", tc.wantSum, got)
 			}
 		})
 	}
 }
 
-func TestRSFlipFlop(t *testing.T) {
-	testCases := []struct {
-		rPin      emitter
-		sPin      emitter
-		wantQ     bool
-		wantQBar  bool
-		wantError string
+func TestAdderFromBuses(t *testing.T) {
+	aBus, _ := NewSwitchBus(32, 0xFFFF0000)
+	bBus, _ := NewSwitchBus(32, 0x0001FFFF)
+
+	a, err := NewAdderFromBuses(aBus, bBus, nil)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	if got := a.Sum().Uint(); got != 0x0000FFFF || !a.CarryOut().Emitting() {
+		t.Errorf("Wanted sum 0000FFFF with a carry, but got %08X (carry %t)", got, a.CarryOut().Emitting())
+	}
+
+	aBus.SetUint(0x12345678)
+	bBus.SetUint(0x11111111)
+
+	if got := a.Sum().Uint(); got != 0x23456789 || a.CarryOut().Emitting() {
+		t.Errorf("Wanted sum 23456789 without a carry, but got %08X (carry %t)", got, a.CarryOut().Emitting())
+	}
+
+	if err := a.UpdateInputs(strings.Repeat("0", 32), strings.Repeat("0", 32)); err == nil {
+		t.Error("Expected an error updating the inputs of an adder driven by Buses, but got none")
+	}
+
+	errorCases := []struct {
+		bus1, bus2 *Bus
+		wantErr    string
 	}{
-		{nil, nil, false, true, ""},
-		{nil, &Battery{}, true, false, ""},
-		{nil, nil, true, false, ""},
-		{&Battery{}, nil, false, true, ""},
-		{nil, nil, false, true, ""},
-		{nil, &Battery{}, true, false, ""},
-		{&Battery{}, &Battery{}, true, false, "Both inputs of a Flip-Flop cannot be powered simultaneously"},
+		{aBus, bBus.Slice(0, 8), "Inputs are not the same width: 32 and 8 bits"},
+		{NewBus(), NewBus(), "Adder width must be at least 1, but got 0"},
 	}
 
-	testName := func(i int) string {
-		var priorR emitter
-		var priorS emitter
+	for _, tc := range errorCases {
+		if _, err := NewAdderFromBuses(tc.bus1, tc.bus2, nil); err == nil || err.Error() != tc.wantErr {
+			t.Errorf("Wanted error %q, but got %v.", tc.wantErr, err)
+		}
+	}
+}
 
-		if i == 0 {
-			priorR = nil
-			priorS = nil
-		} else {
-			priorR = testCases[i-1].rPin
-			priorS = testCases[i-1].sPin
+func TestBinaryAdders_AllArchitectures(t *testing.T) {
+	builders := []struct {
+		architecture string
+		build        func(bus1, bus2 *Bus, carryIn emitter) (BinaryAdder, error)
+	}{
+		{"ripple carry", func(bus1, bus2 *Bus, carryIn emitter) (BinaryAdder, error) {
+			return NewAdderFromBuses(bus1, bus2, carryIn)
+		}},
+		{"carry-lookahead", func(bus1, bus2 *Bus, carryIn emitter) (BinaryAdder, error) {
+			return NewCarryLookaheadAdderFromBuses(bus1, bus2, carryIn)
+		}},
+		{"carry-select", func(bus1, bus2 *Bus, carryIn emitter) (BinaryAdder, error) {
+			return NewCarrySelectAdderFromBuses(bus1, bus2, carryIn)
+		}},
+	}
+
+	for _, b := range builders {
+		t.Run(b.architecture+" empty", func(t *testing.T) {
+			want := "Adder width must be at least 1, but got 0"
+			if _, err := b.build(NewBus(), NewBus(), nil); err == nil || err.Error() != want {
+				t.Errorf("Wanted error %q, but got %v", want, err)
+			}
+		})
+
+		for _, width := range []int{1, 3, 4, 6} {
+			t.Run(fmt.Sprintf("%s %d-bit", b.architecture, width), func(t *testing.T) {
+				aBus, _ := NewSwitchBus(width, 0)
+				bBus, _ := NewSwitchBus(width, 0)
+				carryIn := NewSwitch(false)
+
+				a, err := b.build(aBus, bBus, carryIn)
+				if err != nil {
+					t.Fatal("Unexpected error: " + err.Error())
+				}
+
+				max := uint64(1) << uint(width)
+				for x := uint64(0); x < max; x++ {
+					for y := uint64(0); y < max; y++ {
+						for _, c := range []uint64{0, 1} {
+							aBus.SetUint(x)
+							bBus.SetUint(y)
+							carryIn.Set(c == 1)
+
+							got := a.Sum().Uint()
+							if a.CarryOut().Emitting() {
+								got += max
+							}
+							if got != x+y+c {
+								t.Fatalf("Wanted %d + %d + %d = %d, but got %d", x, y, c, x+y+c, got)
+							}
+						}
+					}
+				}
+			})
 		}
+	}
+}
 
-		return fmt.Sprintf("Stage %d: Switching from [rIn (%T) sIn (%T)] to [rIn (%T) sIn (%T)]", i+1, priorR, priorS, testCases[i].rPin, testCases[i].sPin)
+func TestBinaryAdders_FromStrings(t *testing.T) {
+	bits1 := "1001110110011101"
+	bits2 := "1101011011010110"
+	want := "10111010001110011"
+
+	ripple, _ := NewAdder(16, bits1, bits2, nil)
+	lookahead, _ := NewCarryLookaheadAdder(16, bits1, bits2, nil)
+	carrySelect, _ := NewCarrySelectAdder(16, bits1, bits2, nil)
+
+	for _, a := range []BinaryAdder{ripple, lookahead, carrySelect} {
+		if got := a.String(); got != want {
+			t.Errorf("Wanted answer %s from %T, but got %s", want, a, got)
+		}
 	}
 
-	// starting with no input signals
-	f, err := newRSFlipFLop(nil, nil)
+	if err := lookahead.UpdateInputs("0000000000000001", "1111111111111111"); err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+	if got := lookahead.String(); got != "10000000000000000" {
+		t.Errorf("Wanted answer %s after updating the inputs, but got %s", "10000000000000000", got)
+	}
 
-	if err != nil {
-		t.Error(fmt.Sprintf("Expecting no errors on initial creation but got %s.", err))
+	if err := carrySelect.UpdateInputs("0000000000000001", "1111111111111111"); err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+	if got := carrySelect.String(); got != "10000000000000000" {
+		t.Errorf("Wanted answer %s after updating the inputs, but got %s", "10000000000000000", got)
 	}
 
-	for i, tc := range testCases {
-		t.Run(testName(i), func(t *testing.T) {
-			err := f.updateInputs(tc.rPin, tc.sPin)
+	if _, err := NewCarryLookaheadAdder(4, "101", "0000", nil); err == nil {
+		t.Error("Expected an error building from a 3-bit input, but got none")
+	}
+	if _, err := NewCarrySelectAdder(0, "", "", nil); err == nil {
+		t.Error("Expected an error building a 0-bit adder, but got none")
+	}
+}
 
-			if err != nil && err.Error() != tc.wantError {
-				t.Error(fmt.Sprintf("Wanted error %s but got %s.", tc.wantError, err))
-			}
+func TestCompareAdders(t *testing.T) {
+	testCases := []struct {
+		width     int
+		wantDepth map[string]int
+	}{
+		{4, map[string]int{"ripple carry": 10, "carry-lookahead": 6, "carry-select": 10}}, // a single block, so carry-select is just ripple carry
+		{8, map[string]int{"ripple carry": 18, "carry-lookahead": 8, "carry-select": 13}},
+		{16, map[string]int{"ripple carry": 34, "carry-lookahead": 12, "carry-select": 17}},
+		{32, map[string]int{"ripple carry": 66, "carry-lookahead": 20, "carry-select": 25}},
+	}
 
-			if gotQ, _ := f.qEmitting(); gotQ != tc.wantQ {
-				t.Errorf(fmt.Sprintf("Wanted power of %t on Q, but got %t.", tc.wantQ, gotQ))
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d bits", tc.width), func(t *testing.T) {
+			reports, err := CompareAdders(tc.width)
+			if err != nil {
+				t.Fatal("Unexpected error: " + err.Error())
 			}
 
-			if gotQBar, _ := f.qBarEmitting(); gotQBar != tc.wantQBar {
-				t.Errorf(fmt.Sprintf("Wanted power of %t on QBar, but got %t.", tc.wantQBar, gotQBar))
+			ripple := reports[0]
+			for _, r := range reports {
+				if got := r.GateDepth; got != tc.wantDepth[r.Architecture] {
+					t.Errorf("Wanted %s gate depth %d, but got %d", r.Architecture, tc.wantDepth[r.Architecture], got)
+				}
+				if r.Architecture != "ripple carry" && tc.width > 4 && r.Relays <= ripple.Relays {
+					t.Errorf("Wanted %s to take more relays than ripple carry (%d), but got %d", r.Architecture, ripple.Relays, r.Relays)
+				}
 			}
 		})
 	}
+
+	if _, err := CompareAdders(0); err == nil {
+		t.Error("Expected an error comparing 0-bit adders, but got none")
+	}
 }
 
-func TestLevTrigDLatch(t *testing.T) {
+func TestAddUint(t *testing.T) {
 	testCases := []struct {
-		dataPin   emitter
-		clkPin    emitter
-		wantQ     bool
-		wantQBar  bool
-		wantError string
+		a, b         uint64
+		carryIn      bool
+		width        int
+		wantSum      uint64
+		wantCarryOut bool
 	}{
-		{nil, nil, false, true, ""},
-		{&Battery{}, nil, false, true, ""},
-		{&Battery{}, &Battery{}, true, false, ""},
-		{&Battery{}, nil, true, false, ""},
-		{nil, nil, true, false, ""},
-		{nil, &Battery{}, false, true, ""},
-		{nil, nil, false, true, ""},
-		{&Battery{}, &Battery{}, true, false, ""},
+		{0x9D, 0xD6, false, 8, 0x73, true},
+		{0xFF, 0x00, true, 8, 0x00, true},
+		{0x12, 0x34, true, 8, 0x47, false},
+		{0x9D9D, 0xD6D6, false, 16, 0x7473, true},
+		{0x7FFF, 0x0001, false, 16, 0x8000, false},
+		{0xFFFFFFFF, 0x00000001, false, 32, 0x00000000, true},
+		{0x12345678, 0x11111111, true, 32, 0x2345678A, false},
+		{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, true, 64, 0xFFFFFFFFFFFFFFFF, true},
+		{0x0123456789ABCDEF, 0x1000000000000000, false, 64, 0x1123456789ABCDEF, false},
 	}
 
-	testName := func(i int) string {
-		var priorD emitter
-		var priorC emitter
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-bit %X + %X + %t", tc.width, tc.a, tc.b, tc.carryIn), func(t *testing.T) {
+			var gotSum uint64
+			var gotCarryOut bool
+
+			switch tc.width {
+			case 8:
+				s, c := AddUint8(uint8(tc.a), uint8(tc.b), tc.carryIn)
+				gotSum, gotCarryOut = uint64(s), c
+			case 16:
+				s, c := AddUint16(uint16(tc.a), uint16(tc.b), tc.carryIn)
+				gotSum, gotCarryOut = uint64(s), c
+			case 32:
+				s, c := AddUint32(uint32(tc.a), uint32(tc.b), tc.carryIn)
+				gotSum, gotCarryOut = uint64(s), c
+			case 64:
+				gotSum, gotCarryOut = AddUint64(tc.a, tc.b, tc.carryIn)
+			}
 
-		if i == 0 {
-			priorD = nil
-			priorC = nil
-		} else {
-			priorD = testCases[i-1].dataPin
-			priorC = testCases[i-1].clkPin
-		}
+			if gotSum != tc.wantSum || gotCarryOut != tc.wantCarryOut {
+				t.Errorf("Wanted sum %X (carry %t), but got %X (carry %t)", tc.wantSum, tc.wantCarryOut, gotSum, gotCarryOut)
+			}
+		})
+	}
+}
 
-		return fmt.Sprintf("Stage %d: Switching from [dataIn (%T) clkIn (%T)] to [dataIn (%T) clkIn (%T)]", i+1, priorD, priorC, testCases[i].dataPin, testCases[i].clkPin)
+func TestSub(t *testing.T) {
+	testCases := []struct {
+		a, b           uint64
+		width          int
+		wantDifference uint64
+		wantBorrow     bool
+	}{
+		{100, 58, 8, 42, false},
+		{58, 100, 8, 214, true},
+		{0, 0, 8, 0, false},
+		{0, 1, 8, 255, true},
+		{40000, 1234, 16, 38766, false},
+		{1234, 40000, 16, 26770, true},
+		{0x80000000, 0x00000001, 32, 0x7FFFFFFF, false},
+		{1, 2, 32, 0xFFFFFFFF, true},
+		{0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 64, 0, false},
+		{0, 0xFFFFFFFFFFFFFFFF, 64, 1, true},
 	}
 
-	// starting with no input signals
-	l, err := newLtDLatch(nil, nil)
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-bit %d - %d", tc.width, tc.a, tc.b), func(t *testing.T) {
+			var gotDifference uint64
+			var gotBorrow bool
+
+			switch tc.width {
+			case 8:
+				d, b := Sub8(uint8(tc.a), uint8(tc.b))
+				gotDifference, gotBorrow = uint64(d), b
+			case 16:
+				d, b := Sub16(uint16(tc.a), uint16(tc.b))
+				gotDifference, gotBorrow = uint64(d), b
+			case 32:
+				d, b := Sub32(uint32(tc.a), uint32(tc.b))
+				gotDifference, gotBorrow = uint64(d), b
+			case 64:
+				gotDifference, gotBorrow = Sub64(tc.a, tc.b)
+			}
+
+			if gotDifference != tc.wantDifference || gotBorrow != tc.wantBorrow {
+				t.Errorf("Wanted difference %d (borrow %t), but got %d (borrow %t)", tc.wantDifference, tc.wantBorrow, gotDifference, gotBorrow)
+			}
+		})
+	}
+}
 
+func TestProbe_EightBitAdder(t *testing.T) {
+	testCases := []struct {
+		path       string
+		wantSignal Signal
+		wantError  string
+	}{
+		{"carryOut", High, ""},
+		{"carryIn", Low, ""},
+		{"sum[0]", High, ""},
+		{"sum[7]", Low, ""},
+		{"byte1[7]", High, ""},
+		{"byte2[0]", High, ""},
+		{"fullAdders[7].carry", High, ""},
+		{"fullAdders[7].halfAdder1.sum", Low, ""},
+		{"fullAdders[7].halfAdder1.carry", High, ""},
+		{"fullAdders[7].halfAdder2.pin1", Low, ""},
+		{"fullAdders[3].carryIn", High, ""},
+		{"fullAdders[3].halfAdder1.sum.orGate.relay1.closedOut", High, ""},
+		{"fullAdders[3].halfAdder1.sum.nandGate.out", Low, ""},
+		{"fullAdders[3].halfAdder1.sum.andGate.relay2.bIn", Low, ""},
+		{"fullAdders[3]", X, "Cannot probe a part that is not a wire:"},
+		{"fullAdders[3].bogus", X, "No part named bogus in path:"},
+	}
+
+	a, err := NewEightBitAdder("11111111", "11111111", nil)
 	if err != nil {
-		t.Error(fmt.Sprintf("Expecting no errors on initial creation but got %s.", err))
+		t.Fatal("Unexpected error: " + err.Error())
 	}
 
-	for i, tc := range testCases {
-		t.Run(testName(i), func(t *testing.T) {
-			l.updateInputs(tc.dataPin, tc.clkPin)
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Probing %s", tc.path), func(t *testing.T) {
+			got, err := Probe(a, tc.path)
 
-			if gotQ, _ := l.qEmitting(); gotQ != tc.wantQ {
-				t.Errorf(fmt.Sprintf("Wanted power of %t on Q, but got %t.", tc.wantQ, gotQ))
+			if err != nil && !strings.HasPrefix(err.Error(), tc.wantError) {
+				t.Error("Unexpected error: " + err.Error())
 			}
 
-			if gotQBar, _ := l.qBarEmitting(); gotQBar != tc.wantQBar {
-				t.Errorf(fmt.Sprintf("Wanted power of %t on QBar, but got %t.", tc.wantQBar, gotQBar))
+			if got != tc.wantSignal {
+				t.Errorf("Wanted signal %s, but got %s", tc.wantSignal, got)
 			}
 		})
 	}
 }
+
+func TestProbe_SixteenBitAdder(t *testing.T) {
+	a, err := NewSixteenBitAdder("0000000011111111", "0000000000000001", nil)
+	if err != nil {
+		t.Fatal("Unexpected error: " + err.Error())
+	}
+
+	for i := 0; i < 16; i++ {
+		want := boolSignal(i == 7)
+		if got, _ := Probe(a, fmt.Sprintf("sum[%d]", i)); got != want {
+			t.Errorf("Wanted sum[%d] to be %s, but got %s", i, want, got)
+		}
+	}
+
+	if got, _ := Probe(a, "fullAdders[8].carry"); got != High {
+		t.Errorf("Wanted the carry out of the low byte to be 1, but got %s", got)
+	}
+
+	if got, _ := Probe(a, "fullAdders[7].carryIn"); got != High {
+		t.Errorf("Wanted the carry in of the high byte to be 1, but got %s", got)
+	}
+}
+
+func TestProbe_LevTrigDLatch(t *testing.T) {
+	dataSwitch := NewSwitch(true)
+	clkSwitch := NewSwitch(true)
+
+	l, _ := newLtDLatch(dataSwitch, clkSwitch)
+	l.qEmitting()
+
+	for path, want := range map[string]Signal{"q": High, "qBar": Low, "dataIn": High, "clkIn": High, "dataInv.out": Low, "sAnd.out": High, "rs.sIn": High} {
+		if got, err := Probe(l, path); err != nil || got != want {
+			t.Errorf("Wanted %s to be %s, but got %s (error %v)", path, want, got, err)
+		}
+	}
+}
+
+func TestPortsPartsAndPaths(t *testing.T) {
+	h := newHalfAdder(nil, nil)
+
+	if got, _ := Ports(h, ""); !reflect.DeepEqual(got, []string{"pin1", "pin2", "sum", "carry"}) {
+		t.Errorf("Wanted half adder ports [pin1 pin2 sum carry], but got %v", got)
+	}
+
+	if got, _ := Parts(h, "sum"); !reflect.DeepEqual(got, []string{"orGate", "nandGate", "andGate"}) {
+		t.Errorf("Wanted XOR gate parts [orGate nandGate andGate], but got %v", got)
+	}
+
+	if _, err := Ports(h, "bogus"); err == nil {
+		t.Error("Expected an error asking for ports of a missing part, but got none")
+	}
+
+	paths := Paths(h)
+	for _, p := range paths {
+		if _, err := Probe(h, p); err != nil {
+			t.Errorf("Expected every listed path to be probe-able, but %s got error %s", p, err)
+		}
+	}
+
+	if len(paths) < 40 {
+		t.Errorf("Expected paths all the way down to every relay contact, but only got %d", len(paths))
+	}
+}
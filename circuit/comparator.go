@@ -0,0 +1,275 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Magnitude Comparator
+// Two numbers are equal when every pair of bits is equal (XNOR).  Otherwise the leftmost pair of bits that differ decides which number is bigger, so
+// the first number is greater when, for some bit, all the bits to its left are equal and this bit is 1 in the first number but 0 in the second:
+//
+//	a > b = a0.!b0 + e0.a1.!b1 + e0.e1.a2.!b2 + ...
+//
+// Read signed, the leftmost bit is the sign, where 1 means smaller, so for that bit alone the sense is flipped
+//
+// Just like chaining the carry of an 8-bit adder into another, comparators cascade: the equal, less-than and greater-than outputs of the comparator of
+// the less significant bits only come into play when all of this comparator's bits are equal
+
+type Comparator struct {
+	switchInputs
+	signed    bool
+	bits1     *Bus
+	bits2     *Bus
+	lower     *Comparator // the comparator of the less significant bits, when cascaded
+	gtIn      emitter
+	eqIn      emitter
+	ltIn      emitter
+	sames     []*xnorGate
+	inverts1  []*inverter
+	inverts2  []*inverter
+	gtTerms   []*andGateN
+	ltTerms   []*andGateN
+	gtCascade *andGateN
+	ltCascade *andGateN
+	eq        *andGateN
+	gt        *orGateN
+	lt        *orGateN
+}
+
+// NewComparator builds a comparator of any width (at least 1 bit) from two strings of that many 0s and 1s, read signed (two's complement) or not
+func NewComparator(width int, bits1, bits2 string, signed bool) (*Comparator, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Comparator width must be at least 1, but got ", width))
+	}
+
+	bits1, bits2, err := parseOperandPair(width, bits1, bits2)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBits(width, bits1, bits2); err != nil {
+		return nil, err
+	}
+
+	c := &Comparator{}
+
+	c.bits1Switches = newSwitchBusFromBits(bits1)
+	c.bits2Switches = newSwitchBusFromBits(bits2)
+
+	if err := c.build(c.bits1Switches, c.bits2Switches, signed, nil, &Battery{}, nil); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// NewComparatorFromBuses builds a comparator as wide as the two Buses, wired directly to them, so it follows any change on them
+func NewComparatorFromBuses(bus1, bus2 *Bus, signed bool) (*Comparator, error) {
+	if bus1.Width() != bus2.Width() {
+		return nil, errors.New(fmt.Sprintf("Inputs are not the same width: %d and %d bits", bus1.Width(), bus2.Width()))
+	}
+
+	if bus1.Width() < 1 {
+		return nil, errors.New(fmt.Sprint("Comparator width must be at least 1, but got ", bus1.Width()))
+	}
+
+	c := &Comparator{}
+
+	if err := c.build(bus1, bus2, signed, nil, &Battery{}, nil); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Cascade builds a comparator of the next more significant bits (wired directly to the two Buses), fed by this one's outputs.  The new comparator's
+// outputs compare all the bits of both, so only it can be signed
+func (c *Comparator) Cascade(bus1, bus2 *Bus, signed bool) (*Comparator, error) {
+	if c.signed {
+		return nil, errors.New("Only the most significant comparator of a cascade can be signed")
+	}
+
+	if bus1.Width() != bus2.Width() {
+		return nil, errors.New(fmt.Sprintf("Inputs are not the same width: %d and %d bits", bus1.Width(), bus2.Width()))
+	}
+
+	if bus1.Width() < 1 {
+		return nil, errors.New(fmt.Sprint("Comparator width must be at least 1, but got ", bus1.Width()))
+	}
+
+	upper := &Comparator{lower: c}
+
+	if err := upper.build(bus1, bus2, signed, c.gt, c.eq, c.lt); err != nil {
+		return nil, err
+	}
+
+	return upper, nil
+}
+
+func (c *Comparator) build(bus1, bus2 *Bus, signed bool, gtIn, eqIn, ltIn emitter) error {
+	c.signed = signed
+	c.bits1 = bus1
+	c.bits2 = bus2
+	c.gtIn = gtIn
+	c.eqIn = eqIn
+	c.ltIn = ltIn
+
+	var sames []emitter // the equal bits so far, from the left
+
+	for i := range bus1.wires {
+		a, b := bus1.wires[i], bus2.wires[i]
+
+		same := newXNORGate(a, b)
+		invert1 := newInverter(a)
+		invert2 := newInverter(b)
+
+		c.sames = append(c.sames, same)
+		c.inverts1 = append(c.inverts1, invert1)
+		c.inverts2 = append(c.inverts2, invert2)
+
+		aBigger := []emitter{a, invert2}
+		bBigger := []emitter{invert1, b}
+		if signed && i == 0 {
+			aBigger, bBigger = bBigger, aBigger // a 1 sign bit means negative
+		}
+
+		gtTerm, err := newANDGateN(append(append([]emitter{}, sames...), aBigger...)...)
+		if err != nil {
+			return err
+		}
+		ltTerm, err := newANDGateN(append(append([]emitter{}, sames...), bBigger...)...)
+		if err != nil {
+			return err
+		}
+
+		c.gtTerms = append(c.gtTerms, gtTerm)
+		c.ltTerms = append(c.ltTerms, ltTerm)
+
+		sames = append(sames, same)
+	}
+
+	var err error
+	if c.gtCascade, err = newANDGateN(append(append([]emitter{}, sames...), gtIn)...); err != nil {
+		return err
+	}
+	if c.ltCascade, err = newANDGateN(append(append([]emitter{}, sames...), ltIn)...); err != nil {
+		return err
+	}
+	if c.eq, err = newANDGateN(append(append([]emitter{}, sames...), eqIn)...); err != nil {
+		return err
+	}
+
+	gtTerms, ltTerms := []emitter{c.gtCascade}, []emitter{c.ltCascade}
+	for i := range c.gtTerms {
+		gtTerms = append(gtTerms, c.gtTerms[i])
+		ltTerms = append(ltTerms, c.ltTerms[i])
+	}
+
+	if c.gt, err = newORGateN(gtTerms...); err != nil {
+		return err
+	}
+	if c.lt, err = newORGateN(ltTerms...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Width is how many bits are compared, including those of any comparators cascaded into this one
+func (c *Comparator) Width() int {
+	if c.lower != nil {
+		return c.bits1.Width() + c.lower.Width()
+	}
+
+	return c.bits1.Width()
+}
+
+// Signed is true when the numbers are read as two's complement
+func (c *Comparator) Signed() bool {
+	return c.signed
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing comparator settles on the new answer without being rebuilt
+func (c *Comparator) UpdateInputs(bits1, bits2 string) error {
+	return c.updateSwitches("Comparator", c.bits1.Width(), bits1, bits2)
+}
+
+// Equal is true when the two numbers are equal
+func (c *Comparator) Equal() bool {
+	return c.eq.Emitting()
+}
+
+// LessThan is true when the first number is less than the second
+func (c *Comparator) LessThan() bool {
+	return c.lt.Emitting()
+}
+
+// GreaterThan is true when the first number is greater than the second
+func (c *Comparator) GreaterThan() bool {
+	return c.gt.Emitting()
+}
+
+func (c *Comparator) ports() []namedPart {
+	var ports []namedPart
+
+	for i := range c.bits1.wires {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("bits1[%d]", i), c.bits1.wires[i]},
+			namedPart{fmt.Sprintf("bits2[%d]", i), c.bits2.wires[i]})
+	}
+
+	return append(ports,
+		namedPart{"gtIn", c.gtIn},
+		namedPart{"eqIn", c.eqIn},
+		namedPart{"ltIn", c.ltIn},
+		namedPart{"greaterThan", c.gt},
+		namedPart{"equal", c.eq},
+		namedPart{"lessThan", c.lt})
+}
+
+func (c *Comparator) parts() []namedPart {
+	parts := c.switchParts("bits")
+
+	if c.lower != nil {
+		parts = append(parts, namedPart{"lower", c.lower})
+	}
+
+	for i := range c.sames {
+		parts = append(parts,
+			namedPart{fmt.Sprintf("sames[%d]", i), c.sames[i]},
+			namedPart{fmt.Sprintf("inverts1[%d]", i), c.inverts1[i]},
+			namedPart{fmt.Sprintf("inverts2[%d]", i), c.inverts2[i]},
+			namedPart{fmt.Sprintf("gtTerms[%d]", i), c.gtTerms[i]},
+			namedPart{fmt.Sprintf("ltTerms[%d]", i), c.ltTerms[i]})
+	}
+
+	return append(parts,
+		namedPart{"gtCascade", c.gtCascade},
+		namedPart{"ltCascade", c.ltCascade},
+		namedPart{"equal", c.eq},
+		namedPart{"greaterThan", c.gt},
+		namedPart{"lessThan", c.lt})
+}
+
+func (c *Comparator) outputs() []namedPart {
+	return []namedPart{
+		{"greaterThan", c.gt},
+		{"equal", c.eq},
+		{"lessThan", c.lt},
+	}
+}
+
+// String is how the first number compares to the second ("<", "=" or ">"), or "?" when the outputs don't agree on one (e.g. a floating input)
+func (c *Comparator) String() string {
+	switch {
+	case c.LessThan() && !c.Equal() && !c.GreaterThan():
+		return "<"
+	case c.Equal() && !c.LessThan() && !c.GreaterThan():
+		return "="
+	case c.GreaterThan() && !c.LessThan() && !c.Equal():
+		return ">"
+	}
+
+	return "?"
+}
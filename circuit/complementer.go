@@ -6,45 +6,158 @@ import (
 	"regexp"
 )
 
+// Ones Complementer
+// Each bit goes through an XOR gate along with the signal, so the bits are flipped while the signal is on and pass straight through while it's off
+// signal  bit   out
+// 0       0     0
+// 0       1     1
+// 1       0     1
+// 1       1     0
+
 type onesComplementer struct {
-	xorGates []emitter
+	bitsSwitches *Bus // only when built from bits, otherwise the inputs belong to whatever drives them
+	signal       emitter
+	xorGates     []emitter
 }
 
+// NewOnesComplementer builds a complementer for a string of 0s and 1s, flipped by switches so it can be updated later via UpdateInputs, and whatever
+// drives the signal (e.g. a Switch, or a Battery to always complement)
 func NewOnesComplementer(bits []byte, signal emitter) (*onesComplementer, error) {
 
-	match, err := regexp.MatchString("^[01]+$", string(bits))
+	parsed, err := parseSizedOperand(string(bits))
 	if err != nil {
 		return nil, err
 	}
+	bits = []byte(parsed)
 
-	if !match {
-		err = errors.New(fmt.Sprint("Input bits not in binary format: " + string(bits)))
+	if err := validateComplementBits(bits); err != nil {
 		return nil, err
 	}
 
 	c := &onesComplementer{}
 
-	for _, b := range bits {
-		switch b {
-		case '0':
-			c.xorGates = append(c.xorGates, newXORGate(signal, nil))
-		case '1':
-			c.xorGates = append(c.xorGates, newXORGate(signal, &Battery{}))
+	c.bitsSwitches = newSwitchBusFromBits(string(bits))
+
+	c.build(c.bitsSwitches, signal)
+
+	return c, nil
+}
+
+func validateComplementBits(bits []byte) error {
+	match, err := regexp.MatchString("^[01]+$", string(bits))
+	if err != nil {
+		return err
+	}
+
+	if !match {
+		return errors.New(fmt.Sprint("Input bits not in binary format: " + string(bits)))
+	}
+
+	return nil
+}
+
+// NewOnesComplementerFromBus builds a complementer wired directly to a Bus (e.g. the Sum of an adder), so it follows any change on it
+func NewOnesComplementerFromBus(bus *Bus, signal emitter) *onesComplementer {
+	c := &onesComplementer{}
+
+	c.build(bus, signal)
+
+	return c
+}
+
+func (c *onesComplementer) build(bus *Bus, signal emitter) {
+	c.signal = signal
+
+	for _, w := range bus.wires {
+		c.xorGates = append(c.xorGates, newXORGate(signal, w))
+	}
+}
+
+// Width is how many bits the complementer works on
+func (c *onesComplementer) Width() int {
+	return len(c.xorGates)
+}
+
+// Inverting is true while the signal is on, i.e. the bits are being complemented
+func (c *onesComplementer) Inverting() bool {
+	return signalOf(c.signal) == High
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing complementer settles on the new answer without being rebuilt
+func (c *onesComplementer) UpdateInputs(bits []byte) error {
+	if c.bitsSwitches == nil {
+		return errors.New("Complementer inputs are driven by a Bus, not switches, so they cannot be updated")
+	}
+
+	parsed, err := parseOperand(string(bits), c.Width())
+	if err != nil {
+		return err
+	}
+
+	if err := validateBits(c.Width(), parsed, parsed); err != nil {
+		return err
+	}
+
+	Simultaneously(func() {
+		c.bitsSwitches.setBits(parsed)
+	})
+
+	return nil
+}
+
+func (c *onesComplementer) ports() []namedPart {
+	var ports []namedPart
+
+	if len(c.xorGates) > 0 {
+		ports = append(ports, namedPart{"signal", portOf(c.xorGates[0], "pin1")})
+	}
+
+	for i, x := range c.xorGates {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("bits[%d]", i), portOf(x, "pin2")},
+			namedPart{fmt.Sprintf("complement[%d]", i), x})
+	}
+
+	return ports
+}
+
+func (c *onesComplementer) parts() []namedPart {
+	var parts []namedPart
+
+	if c.bitsSwitches != nil {
+		for i, w := range c.bitsSwitches.wires {
+			parts = append(parts, namedPart{fmt.Sprintf("bitsSwitches[%d]", i), w})
 		}
 	}
 
-	return c, nil
+	for i, x := range c.xorGates {
+		parts = append(parts, namedPart{fmt.Sprintf("xorGates[%d]", i), x})
+	}
+
+	return parts
+}
+
+func (c *onesComplementer) outputs() []namedPart {
+	var parts []namedPart
+
+	for i, x := range c.xorGates {
+		parts = append(parts, namedPart{fmt.Sprintf("xorGates[%d]", i), x})
+	}
+
+	return parts
 }
 
+// ComplementBus returns the (possibly) complemented bits as a Bus that can be wired into other circuits
+func (c *onesComplementer) ComplementBus() *Bus {
+	return NewBus(c.xorGates...)
+}
+
+// Complement is the (possibly) complemented bits
 func (c *onesComplementer) Complement() string {
 	s := ""
 
 	for _, x := range c.xorGates {
-		if x.Emitting() {
-			s += "1"
-		} else {
-			s += "0"
-		}
+		s += x.Signal().String()
 	}
 
 	return s
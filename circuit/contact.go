@@ -1,7 +1,9 @@
 package circuit
 
 type andContact struct {
+	pwrSource
 	sources []emitter
+	welded  bool // a welded contact acts as if every source but the first (the coils) were powered
 }
 
 func newANDContact(emitters ...emitter) *andContact {
@@ -11,36 +13,56 @@ func newANDContact(emitters ...emitter) *andContact {
 		c.sources = append(c.sources, e)
 	}
 
+	for _, s := range c.sources {
+		if s != nil {
+			s.WireUp(c.update)
+		}
+	}
+
 	return c
 }
 
-// Emitting returns true if ALL of its tracked power sources are emitting power
-func (c *andContact) Emitting() bool {
-	if len(c.sources) == 0 {
-		return false
-	}
+// update transmits High if ALL of its tracked power sources are emitting power (Low if any are not, X if any are unknown or floating)
+func (c *andContact) update(Signal) {
+	sigs := make([]Signal, len(c.sources))
 
-	for _, s := range c.sources {
-		if s == nil || !s.Emitting() {
-			return false
+	for i, s := range c.sources {
+		if c.welded && i > 0 {
+			sigs[i] = High
+		} else {
+			sigs[i] = signalOf(s)
 		}
 	}
 
-	return true
+	c.propagate(andSignals(sigs...))
 }
 
 type xContact struct {
+	pwrSource
 	pwrSourceA emitter
 	pwrSourceB emitter
+	welded     bool // a welded contact acts as if source B (the coil) were powered
 }
 
 func newXContact(a, b emitter) *xContact {
-	return &xContact{a, b}
+	x := &xContact{pwrSourceA: a, pwrSourceB: b}
+
+	if a != nil {
+		a.WireUp(x.update)
+	}
+	if b != nil {
+		b.WireUp(x.update)
+	}
+
+	return x
 }
 
-// Emitting returns true if ONLY source A is emitting power
-func (x *xContact) Emitting() bool {
-	return x.pwrSourceA != nil && x.pwrSourceA.Emitting() &&
-		(x.pwrSourceB == nil ||
-			(x.pwrSourceB != nil && !x.pwrSourceB.Emitting()))
+// update transmits High if ONLY source A is emitting power (X if either source is unknown or floating and that could matter)
+func (x *xContact) update(Signal) {
+	b := signalOf(x.pwrSourceB)
+	if x.welded {
+		b = High
+	}
+
+	x.propagate(andSignals(signalOf(x.pwrSourceA), notSignal(b)))
 }
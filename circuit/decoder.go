@@ -0,0 +1,73 @@
+package circuit
+
+import "fmt"
+
+// Decoder
+// Turns on exactly one of its 2^N output lines, the one numbered by the N select lines (read MSB-first), e.g. for N=2
+// sel   lines
+// 00    1000
+// 01    0100
+// 10    0010
+// 11    0001
+//
+// Each line is just an N-input AND gate fed by either the select line or its inverse, so it needs at least 2 select lines
+
+type decoder struct {
+	inverters []*inverter
+	lines     []*andGateN // lines[k] is on when the select lines read k
+}
+
+func newDecoder(sel *Bus) (*decoder, error) {
+	d := &decoder{}
+
+	for _, w := range sel.wires {
+		d.inverters = append(d.inverters, newInverter(w))
+	}
+
+	for k := 0; k < 1<<uint(sel.Width()); k++ {
+		pins := make([]emitter, sel.Width())
+		for j, w := range sel.wires {
+			if k>>uint(sel.Width()-1-j)&1 == 1 {
+				pins[j] = w
+			} else {
+				pins[j] = d.inverters[j]
+			}
+		}
+
+		line, err := newANDGateN(pins...)
+		if err != nil {
+			return nil, err
+		}
+		d.lines = append(d.lines, line)
+	}
+
+	return d, nil
+}
+
+func (d *decoder) ports() []namedPart {
+	var ports []namedPart
+
+	for i, inv := range d.inverters {
+		ports = append(ports, namedPart{fmt.Sprintf("sel[%d]", i), portOf(inv, "in")})
+	}
+
+	for i, line := range d.lines {
+		ports = append(ports, namedPart{fmt.Sprintf("lines[%d]", i), line})
+	}
+
+	return ports
+}
+
+func (d *decoder) parts() []namedPart {
+	var parts []namedPart
+
+	for i, inv := range d.inverters {
+		parts = append(parts, namedPart{fmt.Sprintf("inverters[%d]", i), inv})
+	}
+
+	for i, line := range d.lines {
+		parts = append(parts, namedPart{fmt.Sprintf("lines[%d]", i), line})
+	}
+
+	return parts
+}
@@ -0,0 +1,104 @@
+package circuit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GateDepth is the most gates any signal has to pass through, from the inputs of the circuit to any of its outputs, which is what decides how long the
+// circuit takes to settle.  Gates built from other gates (XOR, or any gate with an inverter on its output) count all the gates inside them
+func GateDepth(circuit outputter) int {
+	depths := map[interface{}]int{}
+
+	var depthOf func(part interface{}) int
+	depthOf = func(part interface{}) int {
+		if part == nil {
+			return 0
+		}
+		if d, ok := depths[part]; ok {
+			return d
+		}
+		depths[part] = 0 // a feedback loop back around to here adds no more depth
+
+		d := 0
+		switch g := part.(type) {
+		case *xorGate:
+			d = depthOf(g.andGate)
+		case *xorGateN:
+			d = depthOf(g.out())
+		case *nandGate2:
+			d = depthOf(g.inverter)
+		case *norGate2:
+			d = depthOf(g.inverter)
+		case *xnorGate:
+			d = depthOf(g.inverter)
+		case *xnorGateN:
+			d = depthOf(g.inverter)
+		case *andGate, *orGate, *nandGate, *norGate, *andGateN, *orGateN, *nandGateN, *norGateN, *inverter:
+			for _, p := range g.(pinned).ports() {
+				if p.name != "out" && depthOf(p.part) > d {
+					d = depthOf(p.part)
+				}
+			}
+			d++
+		}
+
+		depths[part] = d
+		return d
+	}
+
+	depth := 0
+	for _, o := range circuit.outputs() {
+		if d := depthOf(o.part); d > depth {
+			depth = d
+		}
+	}
+
+	return depth
+}
+
+// AdderReport sums up the speed/size trade-off of one adder architecture
+type AdderReport struct {
+	Architecture string
+	Width        int
+	GateDepth    int
+	Relays       int // relay equivalents, counting inverters
+}
+
+func (r AdderReport) String() string {
+	return fmt.Sprintf("%-15s %3d bits   gate depth %4d   relays %6d", r.Architecture, r.Width, r.GateDepth, r.Relays)
+}
+
+// CompareAdders builds every adder architecture at the given width and reports how deep (slow) and big each one is
+func CompareAdders(width int) ([]AdderReport, error) {
+	zeros := strings.Repeat("0", width)
+
+	ripple, err := NewAdder(width, zeros, zeros, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	lookahead, err := NewCarryLookaheadAdder(width, zeros, zeros, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	carrySelect, err := NewCarrySelectAdder(width, zeros, zeros, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []AdderReport
+	for _, a := range []struct {
+		architecture string
+		adder        BinaryAdder
+	}{
+		{"ripple carry", ripple},
+		{"carry-lookahead", lookahead},
+		{"carry-select", carrySelect},
+	} {
+		reports = append(reports, AdderReport{a.architecture, width, GateDepth(a.adder), TakeCensus(a.adder).RelayEquivalents()})
+	}
+
+	return reports, nil
+}
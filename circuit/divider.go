@@ -0,0 +1,233 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Restoring Divider
+// Just like long division on paper, one stage per quotient bit (from the left).  Each stage brings down the next bit of the dividend onto the remainder
+// so far and tries subtracting the divisor from it.  If that didn't have to borrow, the divisor fit: the quotient bit is 1 and the difference is the new
+// remainder.  Otherwise the quotient bit is 0 and the remainder is restored to what it was before the subtraction
+//       00000011 r 00000001
+//      ---------
+// 011 ) 00001010
+//
+// Dividing by zero doesn't stop the circuit (every subtraction fits, so the quotient comes out all 1s), it is only flagged
+
+type Divider struct {
+	switchInputs
+	dividend  *Bus
+	divisor   *Bus
+	stages    []*divideStage // stages[i] works out bit i of the quotient, from the left
+	divByZero emitter
+}
+
+// divideStage subtracts the divisor from the remainder so far (with the next dividend bit brought down), keeping either the difference or the original
+type divideStage struct {
+	sub       *Subtractor
+	keeps     []*andGate // the difference, only when the divisor fit
+	restores  []*andGate // the original, only when it didn't
+	remainder []*orGate
+}
+
+// NewDivider builds a divider of any width (at least 1 bit), dividing the first string of 0s and 1s by the second
+func NewDivider(width int, bits1, bits2 string) (*Divider, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Divider width must be at least 1, but got ", width))
+	}
+
+	bits1, bits2, err := parseOperandPair(width, bits1, bits2)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBits(width, bits1, bits2); err != nil {
+		return nil, err
+	}
+
+	d := &Divider{}
+
+	d.bits1Switches = newSwitchBusFromBits(bits1)
+	d.bits2Switches = newSwitchBusFromBits(bits2)
+
+	if err := d.build(d.bits1Switches, d.bits2Switches); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// NewDividerFromBuses builds a divider as wide as the two Buses, wired directly to them, so it follows any change on them
+func NewDividerFromBuses(dividend, divisor *Bus) (*Divider, error) {
+	if dividend.Width() != divisor.Width() {
+		return nil, errors.New(fmt.Sprintf("Inputs are not the same width: %d and %d bits", dividend.Width(), divisor.Width()))
+	}
+
+	if dividend.Width() < 1 {
+		return nil, errors.New(fmt.Sprint("Divider width must be at least 1, but got ", dividend.Width()))
+	}
+
+	d := &Divider{}
+
+	if err := d.build(dividend, divisor); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *Divider) build(dividend, divisor *Bus) error {
+	n := dividend.Width()
+
+	d.dividend = dividend
+	d.divisor = divisor
+
+	remainder := make([]emitter, n) // nothing (all 0s) before the first stage
+	widened := NewBus(append([]emitter{nil}, divisor.wires...)...)
+
+	for i := 0; i < n; i++ {
+		broughtDown := append(append([]emitter{}, remainder...), dividend.wires[i])
+
+		sub, err := NewSubtractorFromBuses(NewBus(broughtDown...), widened) // both n+1 bits wide
+		if err != nil {
+			return err
+		}
+		s := &divideStage{sub: sub}
+
+		fits := s.sub.noBorrow
+		difference := s.sub.Difference()
+
+		// the remainder is always smaller than the divisor, so the leftmost bit is always 0 and can be dropped
+		remainder = make([]emitter, n)
+		for j := 1; j <= n; j++ {
+			keep := newANDGate(difference.wires[j], fits)
+			restore := newANDGate(broughtDown[j], s.sub.borrow)
+			r := newORGate(keep, restore)
+
+			s.keeps = append(s.keeps, keep)
+			s.restores = append(s.restores, restore)
+			s.remainder = append(s.remainder, r)
+			remainder[j-1] = r
+		}
+
+		d.stages = append(d.stages, s)
+	}
+
+	if n == 1 {
+		d.divByZero = newInverter(divisor.wires[0])
+		return nil
+	}
+
+	divByZero, err := newNORGateN(divisor.wires...)
+	if err != nil {
+		return err
+	}
+	d.divByZero = divByZero
+
+	return nil
+}
+
+func (s *divideStage) ports() []namedPart {
+	ports := []namedPart{{"quotient", s.sub.noBorrow}}
+
+	for i, r := range s.remainder {
+		ports = append(ports, namedPart{fmt.Sprintf("remainder[%d]", i), r})
+	}
+
+	return ports
+}
+
+func (s *divideStage) parts() []namedPart {
+	parts := []namedPart{{"sub", s.sub}}
+
+	for i := range s.remainder {
+		parts = append(parts,
+			namedPart{fmt.Sprintf("keeps[%d]", i), s.keeps[i]},
+			namedPart{fmt.Sprintf("restores[%d]", i), s.restores[i]},
+			namedPart{fmt.Sprintf("remainder[%d]", i), s.remainder[i]})
+	}
+
+	return parts
+}
+
+// Width is how many bits the dividend, divisor, quotient and remainder each have
+func (d *Divider) Width() int {
+	return d.dividend.Width()
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing divider settles on the new answer without being rebuilt
+func (d *Divider) UpdateInputs(bits1, bits2 string) error {
+	return d.updateSwitches("Divider", d.Width(), bits1, bits2)
+}
+
+// Quotient returns the quotient bits as a Bus that can be wired into other circuits
+func (d *Divider) Quotient() *Bus {
+	b := &Bus{}
+
+	for _, s := range d.stages {
+		b.wires = append(b.wires, s.sub.noBorrow)
+	}
+
+	return b
+}
+
+// Remainder returns the remainder bits (what's left after the last stage) as a Bus that can be wired into other circuits
+func (d *Divider) Remainder() *Bus {
+	b := &Bus{}
+
+	for _, r := range d.stages[len(d.stages)-1].remainder {
+		b.wires = append(b.wires, r)
+	}
+
+	return b
+}
+
+// DivideByZero is true when every bit of the divisor is 0, in which case the quotient and remainder are meaningless
+func (d *Divider) DivideByZero() bool {
+	return d.divByZero.Emitting()
+}
+
+func (d *Divider) ports() []namedPart {
+	var ports []namedPart
+
+	quotient, remainder := d.Quotient(), d.Remainder()
+	for i := range d.dividend.wires {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("dividend[%d]", i), d.dividend.wires[i]},
+			namedPart{fmt.Sprintf("divisor[%d]", i), d.divisor.wires[i]},
+			namedPart{fmt.Sprintf("quotient[%d]", i), quotient.wires[i]},
+			namedPart{fmt.Sprintf("remainder[%d]", i), remainder.wires[i]})
+	}
+
+	return append(ports, namedPart{"divByZero", d.divByZero})
+}
+
+func (d *Divider) parts() []namedPart {
+	parts := d.switchParts("bits")
+
+	for i, s := range d.stages {
+		parts = append(parts, namedPart{fmt.Sprintf("stages[%d]", i), s})
+	}
+
+	return append(parts, namedPart{"divByZero", d.divByZero})
+}
+
+func (d *Divider) outputs() []namedPart {
+	var parts []namedPart
+
+	for i, q := range d.Quotient().wires {
+		parts = append(parts, namedPart{fmt.Sprintf("quotient[%d]", i), q})
+	}
+
+	for i, r := range d.Remainder().wires {
+		parts = append(parts, namedPart{fmt.Sprintf("remainder[%d]", i), r})
+	}
+
+	return append(parts, namedPart{"divByZero", d.divByZero})
+}
+
+// String is the quotient and remainder, e.g. "00000011 r 00000001"
+func (d *Divider) String() string {
+	return d.Quotient().String() + " r " + d.Remainder().String()
+}
@@ -0,0 +1,137 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+)
+
+type FaultKind int
+
+const (
+	StuckAt0      FaultKind = iota // the named wire always reads 0, no matter what drives it
+	StuckAt1                       // the named wire always reads 1, no matter what drives it
+	OpenContact                    // the named relay's closed contact never makes, so no power ever passes through it
+	WeldedContact                  // the named relay's armature is welded shut, as if its coil were always energized
+)
+
+func (k FaultKind) String() string {
+	switch k {
+	case StuckAt0:
+		return "stuck-at-0"
+	case StuckAt1:
+		return "stuck-at-1"
+	case OpenContact:
+		return "open contact"
+	default:
+		return "welded contact"
+	}
+}
+
+// Fault describes a deliberate break somewhere inside a circuit.  Path names a wire (for stuck-at faults) or a relay (for contact faults), e.g. "fullAdders[3].halfAdder2.carry" or "fullAdders[3].carry.relay1"
+type Fault struct {
+	Kind FaultKind
+	Path string
+}
+
+// FaultDiff is an output that reads differently with a fault injected than it does fault-free
+type FaultDiff struct {
+	Output string
+	Want   Signal
+	Got    Signal
+}
+
+// outputter is implemented by circuits that can report their outputs (each part being an emitter) for comparing fault-free and faulted runs
+type outputter interface {
+	assembly
+	outputs() []namedPart
+}
+
+// InjectFault breaks the circuit as described by the fault, returning a func that repairs it again
+func InjectFault(circuit assembly, f Fault) (func(), error) {
+	part, err := findPart(circuit, f.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch f.Kind {
+	case StuckAt0, StuckAt1:
+		p := sourceOf(part)
+		if p == nil {
+			return nil, errors.New(fmt.Sprint("Cannot inject a " + f.Kind.String() + " fault into a part that is not a wire: " + f.Path))
+		}
+
+		p.stickAt(boolSignal(f.Kind == StuckAt1))
+		return p.unstick, nil
+	case OpenContact, WeldedContact:
+		r, ok := part.(*relay)
+		if !ok {
+			return nil, errors.New(fmt.Sprint("Cannot inject a " + f.Kind.String() + " fault into a part that is not a relay: " + f.Path))
+		}
+
+		if f.Kind == OpenContact {
+			r.closedOut.stickAt(Low)
+		} else {
+			r.weld()
+		}
+		return r.repair, nil
+	}
+
+	return nil, errors.New(fmt.Sprint("Unknown fault kind: ", int(f.Kind)))
+}
+
+// FaultDiffs runs the circuit with and without the fault, returning every output that differs (the circuit is left repaired afterward)
+func FaultDiffs(circuit outputter, f Fault) ([]FaultDiff, error) {
+	outputs := circuit.outputs()
+
+	want := make([]Signal, len(outputs))
+	for i, o := range outputs {
+		want[i] = signalOf(o.part.(emitter))
+	}
+
+	repair, err := InjectFault(circuit, f)
+	if err != nil {
+		return nil, err
+	}
+	defer repair()
+
+	var diffs []FaultDiff
+	for i, o := range outputs {
+		if got := signalOf(o.part.(emitter)); got != want[i] {
+			diffs = append(diffs, FaultDiff{o.name, want[i], got})
+		}
+	}
+
+	return diffs, nil
+}
+
+// stickAt forces the source to hold a signal regardless of what it is told to transmit
+func (p *pwrSource) stickAt(sig Signal) {
+	p.stuck = true
+	p.stuckSignal = sig
+	p.transmit(p.unfaulted)
+}
+
+// unstick lets the source go back to transmitting whatever it would have without the fault
+func (p *pwrSource) unstick() {
+	p.stuck = false
+	p.transmit(p.unfaulted)
+}
+
+// weld acts as though the relay's coil is always energized, so its closed contact passes whatever power comes in and its open contact passes none
+func (r *relay) weld() {
+	r.openOut.welded = true
+	r.closedOut.welded = true
+
+	r.openOut.update(Low)
+	r.closedOut.update(Low)
+}
+
+// repair clears any contact faults from the relay
+func (r *relay) repair() {
+	r.openOut.welded = false
+	r.closedOut.welded = false
+	r.closedOut.unstick()
+
+	r.openOut.update(Low)
+	r.closedOut.update(Low)
+}
@@ -0,0 +1,145 @@
+package circuit
+
+// settleLimit is how many trips around the feedback loops of a circuit are allowed before the loops still changing are declared to never settle
+const settleLimit = 1000
+
+// settling is true while a change is still being pushed through the circuit, so any jumpers hit along the way wait their turn instead of recursing
+var settling bool
+
+// pendingJumpers are the jumpers waiting to pass along a new signal on the next trip around their loops
+var pendingJumpers []*jumper
+
+// jumper is a wire that can be connected (or re-connected) to its source after whatever it feeds is already built.  That is what makes feedback loops
+// possible, e.g. the output of one NOR gate wired back into the input of another NOR gate that feeds it:
+//
+//	loop := newJumper(X)
+//	rNor := newNORGate(rIn, loop)
+//	sNor := newNORGate(sIn, rNor)
+//	loop.connect(sNor)
+//
+// A jumper never passes a change along immediately.  Changes wait until the current push through the circuit is done, then all waiting jumpers pass theirs
+// along together (one trip around the loops), over and over until nothing changes.  A loop that is still changing after settleLimit trips goes X
+type jumper struct {
+	pwrSource
+	in        emitter
+	gen       int // bumped on each connect so a replaced source can no longer push changes through
+	next      Signal
+	queued    bool
+	unsettled bool // the jumper was still changing when its loop gave up settling
+}
+
+func newJumper(init Signal) *jumper {
+	j := &jumper{}
+
+	j.signal = init
+	j.unfaulted = init
+
+	return j
+}
+
+// connect wires the jumper to its source, replacing any earlier source (a nil source means no power, as always)
+func (j *jumper) connect(e emitter) {
+	j.gen++
+	j.in = e
+
+	if e == nil {
+		j.update(Low)
+		return
+	}
+
+	gen := j.gen
+	e.WireUp(func(sig Signal) {
+		if j.gen == gen {
+			j.update(sig)
+		}
+	})
+}
+
+func (j *jumper) update(sig Signal) {
+	j.next = sig
+
+	if !j.queued {
+		j.queued = true
+		pendingJumpers = append(pendingJumpers, j)
+	}
+
+	if !settling {
+		Simultaneously(func() {})
+	}
+}
+
+func (j *jumper) ports() []namedPart {
+	return []namedPart{
+		{"in", j.in},
+		{"out", j},
+	}
+}
+
+// Simultaneously makes all the changes (e.g. flipping several Switches) as if they happened at the same instant, only letting any feedback loops
+// react once all of them are made
+func Simultaneously(changes func()) {
+	if settling {
+		changes()
+		return
+	}
+
+	settling = true
+	defer func() { settling = false }()
+
+	changes()
+	settle()
+}
+
+// settle keeps sending the waiting jumpers' signals around their loops until nothing changes, or until settleLimit trips, at which point the jumpers
+// still changing give up and go X
+func settle() {
+	for trip := 0; len(pendingJumpers) > 0; trip++ {
+		jumpers := pendingJumpers
+		pendingJumpers = nil
+
+		// every jumper passes along what it was told before this trip started, so two loops racing each other really race
+		sigs := make([]Signal, len(jumpers))
+		for i, j := range jumpers {
+			j.queued = false
+			j.unsettled = trip >= settleLimit
+
+			sigs[i] = j.next
+			if j.unsettled {
+				sigs[i] = X
+			}
+		}
+
+		for i, j := range jumpers {
+			j.transmit(sigs[i])
+		}
+	}
+}
+
+// UnsettledLoops returns the path to every feedback loop jumper in the circuit that never settled the last time it was changed (e.g. a ring of inverters,
+// or both inputs of an RS flip-flop released at the same instant)
+func UnsettledLoops(circuit assembly) []string {
+	var paths []string
+	seen := map[interface{}]bool{}
+
+	var walk func(prefix string, part interface{})
+	walk = func(prefix string, part interface{}) {
+		if part == nil || seen[part] {
+			return
+		}
+		seen[part] = true
+
+		if j, ok := part.(*jumper); ok && j.unsettled {
+			paths = append(paths, prefix[:len(prefix)-1])
+		}
+
+		if a, ok := part.(assembly); ok {
+			for _, p := range a.parts() {
+				walk(prefix+p.name+".", p.part)
+			}
+		}
+	}
+
+	walk("", circuit)
+
+	return paths
+}
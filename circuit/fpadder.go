@@ -0,0 +1,637 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Half Precision Floating Point Adder
+// Adds two IEEE 754 half precision (16-bit) floating point numbers, each a sign bit, a 5-bit exponent (biased by 15) and a 10-bit fraction
+// sign  exponent  fraction
+// 0     01111     0000000000   1.0
+// 1     10000     1000000000   -3.0
+//
+// The same way it's done by hand: the number with the smaller magnitude has its significand (the fraction with its hidden 1) shifted right until the
+// exponents line up, the significands are added (or subtracted when the signs differ), the answer is shifted back until its leading 1 is in place, and then
+// rounded to the nearest even.  Guard, round and sticky bits keep enough of what was shifted away for the rounding to come out exactly right
+//
+// Zeros and subnormals (an exponent of 00000, with no hidden 1) are added the same as any other number.  Infinity in gives infinity out, as does an answer
+// too big for 16 bits, and NaN in (or infinity minus infinity) gives a NaN out
+
+type HalfPrecisionAdder struct {
+	switchInputs
+	a            *floatUnpacker
+	b            *floatUnpacker
+	magnitude    *Comparator // swaps the inputs when |A| < |B|, so the bigger one is always on the left
+	swap         *floatSwap
+	expDiff      *Subtractor
+	align        *BarrelShifter // shifts the smaller significand right by the difference in exponents
+	alignSticky  *orGateN       // anything shifted past the round bit
+	effectiveSub *xorGate
+	mantissas    *AdderSubtractor
+	zeros        *leadingZeroCounter
+	expMinusOne  *Decrementer
+	subnormal    *Comparator // shifting left by all the leading zeros would take the exponent below 1
+	subnormalInv *inverter
+	leftAmount   []*mux2
+	normLeft     *BarrelShifter
+	rightSticky  *orGate
+	carryInv     *inverter
+	normalized   []*mux2 // the significand plus guard, round and sticky bits, shifted left or right to put the leading 1 in place
+	expLeft      *Subtractor
+	expRight     *Incrementer
+	exponent     []*mux2
+	expEncoded   []*andGate // an exponent of 0 when there's no leading 1 (a subnormal)
+	roundTail    *orGateN
+	roundUp      *andGate
+	rounded      *Adder
+	special      *floatSpecials
+}
+
+// floatUnpacker pulls apart a half precision number, sorting out what kind of number it is along with its significand and exponent
+type floatUnpacker struct {
+	bits        *Bus
+	expZero     *norGateN
+	expOnes     *andGateN
+	fracZero    *norGateN
+	fracNonZero *inverter
+	hidden      *inverter // the hidden 1 in front of the fraction, which subnormals don't have
+	effExpLSB   *orGate   // subnormals are scaled as if their exponent were 00001
+	nan         *andGate
+	inf         *andGate
+}
+
+func newFloatUnpacker(bits *Bus) *floatUnpacker {
+	u := &floatUnpacker{bits: bits}
+
+	u.expZero, _ = newNORGateN(bits.wires[1:6]...)
+	u.expOnes, _ = newANDGateN(bits.wires[1:6]...)
+	u.fracZero, _ = newNORGateN(bits.wires[6:16]...)
+	u.fracNonZero = newInverter(u.fracZero)
+	u.hidden = newInverter(u.expZero)
+	u.effExpLSB = newORGate(bits.wires[5], u.expZero)
+	u.nan = newANDGate(u.expOnes, u.fracNonZero)
+	u.inf = newANDGate(u.expOnes, u.fracZero)
+
+	return u
+}
+
+// unpacked is the sign, the 5-bit exponent (with subnormals at 00001) and the 11-bit significand (the hidden 1 and the fraction)
+func (u *floatUnpacker) unpacked() *Bus {
+	return NewBus(u.bits.wires[0:5]...).Concat(NewBus(u.effExpLSB, u.hidden), u.bits.Slice(6, 16))
+}
+
+func (u *floatUnpacker) ports() []namedPart {
+	var ports []namedPart
+
+	for i, w := range u.bits.wires {
+		ports = append(ports, namedPart{fmt.Sprintf("bits[%d]", i), w})
+	}
+
+	return append(ports,
+		namedPart{"hidden", u.hidden},
+		namedPart{"nan", u.nan},
+		namedPart{"inf", u.inf})
+}
+
+func (u *floatUnpacker) parts() []namedPart {
+	return []namedPart{
+		{"expZero", u.expZero},
+		{"expOnes", u.expOnes},
+		{"fracZero", u.fracZero},
+		{"fracNonZero", u.fracNonZero},
+		{"hidden", u.hidden},
+		{"effExpLSB", u.effExpLSB},
+		{"nan", u.nan},
+		{"inf", u.inf},
+	}
+}
+
+// floatSwap passes two unpacked numbers through as they are, or swapped
+type floatSwap struct {
+	swapInv *inverter
+	big     []*mux2
+	small   []*mux2
+}
+
+func newFloatSwap(a, b *Bus, swap emitter) *floatSwap {
+	s := &floatSwap{swapInv: newInverter(swap)}
+
+	for i := range a.wires {
+		s.big = append(s.big, newMux2(a.wires[i], b.wires[i], swap, s.swapInv))
+		s.small = append(s.small, newMux2(b.wires[i], a.wires[i], swap, s.swapInv))
+	}
+
+	return s
+}
+
+func (s *floatSwap) bigBus() *Bus {
+	b := &Bus{}
+	for _, m := range s.big {
+		b.wires = append(b.wires, m)
+	}
+	return b
+}
+
+func (s *floatSwap) smallBus() *Bus {
+	b := &Bus{}
+	for _, m := range s.small {
+		b.wires = append(b.wires, m)
+	}
+	return b
+}
+
+func (s *floatSwap) ports() []namedPart {
+	ports := []namedPart{{"swap", portOf(s.swapInv, "in")}}
+
+	for i := range s.big {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("big[%d]", i), s.big[i]},
+			namedPart{fmt.Sprintf("small[%d]", i), s.small[i]})
+	}
+
+	return ports
+}
+
+func (s *floatSwap) parts() []namedPart {
+	parts := []namedPart{{"swapInv", s.swapInv}}
+
+	for i := range s.big {
+		parts = append(parts,
+			namedPart{fmt.Sprintf("big[%d]", i), s.big[i]},
+			namedPart{fmt.Sprintf("small[%d]", i), s.small[i]})
+	}
+
+	return parts
+}
+
+// leadingZeroCounter counts the 0s in front of the first 1 (MSB-first), counting all of them when there's no 1 at all
+type leadingZeroCounter struct {
+	before []*orGate   // before[i] is on when there's a 1 somewhere in front of bit i+1
+	none   []*inverter // none[i] is on when there's no 1 in front of bit i+1
+	first  []*andGate  // first[i] is on when bit i is the first 1
+	count  []*orGateN  // MSB-first
+}
+
+func newLeadingZeroCounter(bits *Bus, countWidth int) *leadingZeroCounter {
+	z := &leadingZeroCounter{}
+
+	lines := make([]emitter, bits.Width()+1) // lines[i] is on when there are exactly i leading zeros
+	lines[0] = bits.wires[0]
+
+	var seen emitter = bits.wires[0]
+	for i := 1; i < bits.Width(); i++ {
+		none := newInverter(seen)
+		first := newANDGate(bits.wires[i], none)
+		z.none = append(z.none, none)
+		z.first = append(z.first, first)
+		lines[i] = first
+
+		before := newORGate(seen, bits.wires[i])
+		z.before = append(z.before, before)
+		seen = before
+	}
+
+	allZero := newInverter(seen)
+	z.none = append(z.none, allZero)
+	lines[bits.Width()] = allZero
+
+	for k := countWidth - 1; k >= 0; k-- {
+		var pins []emitter
+		for i, line := range lines {
+			if i>>uint(k)&1 == 1 {
+				pins = append(pins, line)
+			}
+		}
+
+		for len(pins) < 2 {
+			pins = append(pins, nil) // a count bit no line reaches (or just one) still needs a whole gate
+		}
+
+		count, _ := newORGateN(pins...)
+		z.count = append(z.count, count)
+	}
+
+	return z
+}
+
+func (z *leadingZeroCounter) countBus() *Bus {
+	b := &Bus{}
+	for _, c := range z.count {
+		b.wires = append(b.wires, c)
+	}
+	return b
+}
+
+func (z *leadingZeroCounter) ports() []namedPart {
+	var ports []namedPart
+
+	for i, c := range z.count {
+		ports = append(ports, namedPart{fmt.Sprintf("count[%d]", i), c})
+	}
+
+	return ports
+}
+
+func (z *leadingZeroCounter) parts() []namedPart {
+	var parts []namedPart
+
+	for i, b := range z.before {
+		parts = append(parts, namedPart{fmt.Sprintf("before[%d]", i), b})
+	}
+
+	for i, n := range z.none {
+		parts = append(parts, namedPart{fmt.Sprintf("none[%d]", i), n})
+	}
+
+	for i, f := range z.first {
+		parts = append(parts, namedPart{fmt.Sprintf("first[%d]", i), f})
+	}
+
+	for i, c := range z.count {
+		parts = append(parts, namedPart{fmt.Sprintf("count[%d]", i), c})
+	}
+
+	return parts
+}
+
+// floatSpecials overrides the answer for NaN, infinity and zero, leaving everything else alone
+type floatSpecials struct {
+	anyNaN     *orGate
+	infClash   *andGateN // infinity minus infinity
+	nan        *orGate
+	anyInf     *orGate
+	expOnes    *andGateN // the answer rounded up past the biggest exponent
+	overflow   *orGate
+	inf        *orGate
+	special    *orGate // NaN or infinity, so the exponent is all 1s
+	notSpecial *inverter
+	resultZero *norGateN
+	notAnyInf  *inverter
+	zero       *andGate
+	zeroSign   *andGate // -0 only when both inputs are -0
+	zeroInv    *inverter
+	signMux    *mux2
+	notNaN     *inverter
+	sign       *andGate
+	exponent   []*orGate
+	fraction   []*andGate
+	quiet      *orGate // the leading fraction bit that makes a NaN
+}
+
+func (h *HalfPrecisionAdder) buildSpecials() {
+	s := &floatSpecials{}
+
+	rounded := h.rounded.Sum()
+
+	s.anyNaN = newORGate(h.a.nan, h.b.nan)
+	s.infClash, _ = newANDGateN(h.a.inf, h.b.inf, h.effectiveSub)
+	s.nan = newORGate(s.anyNaN, s.infClash)
+	s.anyInf = newORGate(h.a.inf, h.b.inf)
+	s.expOnes, _ = newANDGateN(rounded.wires[0:5]...)
+	s.overflow = newORGate(s.expOnes, h.rounded.carryOut)
+	s.inf = newORGate(s.anyInf, s.overflow)
+	s.special = newORGate(s.nan, s.inf)
+	s.notSpecial = newInverter(s.special)
+
+	s.resultZero, _ = newNORGateN(h.mantissas.Result().wires...)
+	s.notAnyInf = newInverter(s.anyInf)
+	s.zero = newANDGate(s.resultZero, s.notAnyInf)
+	s.zeroSign = newANDGate(h.a.bits.wires[0], h.b.bits.wires[0])
+	s.zeroInv = newInverter(s.zero)
+	s.signMux = newMux2(h.swap.big[0], s.zeroSign, s.zero, s.zeroInv)
+	s.notNaN = newInverter(s.nan)
+	s.sign = newANDGate(s.signMux, s.notNaN)
+
+	for _, w := range rounded.wires[0:5] {
+		s.exponent = append(s.exponent, newORGate(w, s.special))
+	}
+
+	for _, w := range rounded.wires[5:15] {
+		s.fraction = append(s.fraction, newANDGate(w, s.notSpecial))
+	}
+	s.quiet = newORGate(s.fraction[0], s.nan)
+
+	h.special = s
+}
+
+func (s *floatSpecials) sumBus() *Bus {
+	b := NewBus(s.sign)
+
+	for _, e := range s.exponent {
+		b.wires = append(b.wires, e)
+	}
+
+	b.wires = append(b.wires, s.quiet)
+	for _, f := range s.fraction[1:] {
+		b.wires = append(b.wires, f)
+	}
+
+	return b
+}
+
+func (s *floatSpecials) ports() []namedPart {
+	var ports []namedPart
+
+	for i, w := range s.sumBus().wires {
+		ports = append(ports, namedPart{fmt.Sprintf("sum[%d]", i), w})
+	}
+
+	return append(ports,
+		namedPart{"nan", s.nan},
+		namedPart{"inf", s.inf},
+		namedPart{"zero", s.zero})
+}
+
+func (s *floatSpecials) parts() []namedPart {
+	parts := []namedPart{
+		{"anyNaN", s.anyNaN},
+		{"infClash", s.infClash},
+		{"nan", s.nan},
+		{"anyInf", s.anyInf},
+		{"expOnes", s.expOnes},
+		{"overflow", s.overflow},
+		{"inf", s.inf},
+		{"special", s.special},
+		{"notSpecial", s.notSpecial},
+		{"resultZero", s.resultZero},
+		{"notAnyInf", s.notAnyInf},
+		{"zero", s.zero},
+		{"zeroSign", s.zeroSign},
+		{"zeroInv", s.zeroInv},
+		{"signMux", s.signMux},
+		{"notNaN", s.notNaN},
+		{"sign", s.sign},
+	}
+
+	for i, e := range s.exponent {
+		parts = append(parts, namedPart{fmt.Sprintf("exponent[%d]", i), e})
+	}
+
+	for i, f := range s.fraction {
+		parts = append(parts, namedPart{fmt.Sprintf("fraction[%d]", i), f})
+	}
+
+	return append(parts, namedPart{"quiet", s.quiet})
+}
+
+// NewHalfPrecisionAdder builds a floating point adder from two strings of 16 0s and 1s (or number literals, e.g. 0x3C00 for 1.0)
+func NewHalfPrecisionAdder(bits1, bits2 string) (*HalfPrecisionAdder, error) {
+	bits1, bits2, err := parseOperandPair(16, bits1, bits2)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBits(16, bits1, bits2); err != nil {
+		return nil, err
+	}
+
+	h := &HalfPrecisionAdder{}
+
+	h.bits1Switches = newSwitchBusFromBits(bits1)
+	h.bits2Switches = newSwitchBusFromBits(bits2)
+
+	h.build(h.bits1Switches, h.bits2Switches)
+
+	return h, nil
+}
+
+// NewHalfPrecisionAdderFromBuses builds a floating point adder wired directly to two 16-bit Buses, so it follows any change on them
+func NewHalfPrecisionAdderFromBuses(bus1, bus2 *Bus) (*HalfPrecisionAdder, error) {
+	if bus1.Width() != 16 {
+		return nil, errors.New(fmt.Sprint("First input not a 16-bit Bus, width: ", bus1.Width()))
+	}
+
+	if bus2.Width() != 16 {
+		return nil, errors.New(fmt.Sprint("Second input not a 16-bit Bus, width: ", bus2.Width()))
+	}
+
+	h := &HalfPrecisionAdder{}
+
+	h.build(bus1, bus2)
+
+	return h, nil
+}
+
+func (h *HalfPrecisionAdder) build(bus1, bus2 *Bus) {
+	h.a = newFloatUnpacker(bus1)
+	h.b = newFloatUnpacker(bus2)
+
+	// line up the bigger number on the left, then shift the smaller one right by the difference in exponents
+	h.magnitude, _ = NewComparatorFromBuses(bus1.Slice(1, 16), bus2.Slice(1, 16), false)
+	h.swap = newFloatSwap(h.a.unpacked(), h.b.unpacked(), h.magnitude.lt)
+
+	big, small := h.swap.bigBus(), h.swap.smallBus()
+	bigExp, bigSig := big.Slice(1, 6), big.Slice(6, 17)
+
+	h.expDiff, _ = NewSubtractorFromBuses(bigExp, small.Slice(1, 6))
+	h.align, _ = NewBarrelShifterFromBuses(ShiftRight, small.Slice(6, 17).Concat(NewBus(make([]emitter, 31)...)), h.expDiff.Difference())
+
+	aligned := h.align.Result()
+	h.alignSticky, _ = newORGateN(aligned.wires[13:]...)
+
+	// add (or subtract) the significands, with a spare bit on the left for a carry and guard, round and sticky bits on the right
+	h.effectiveSub = newXORGate(bus1.wires[0], bus2.wires[0])
+	h.mantissas, _ = NewAdderSubtractorFromBuses(
+		NewBus(nil).Concat(bigSig, NewBus(nil, nil, nil)),
+		NewBus(nil).Concat(aligned.Slice(0, 13), NewBus(h.alignSticky)),
+		h.effectiveSub)
+
+	result := h.mantissas.Result()
+	carry := result.wires[0]
+
+	// normalize, either right by 1 after a carry, or left by the leading zeros (but not so far the exponent drops below 1)
+	h.zeros = newLeadingZeroCounter(result.Slice(1, 15), 5)
+	h.expMinusOne = NewDecrementerFromBus(bigExp)
+	h.subnormal, _ = NewComparatorFromBuses(h.zeros.countBus(), h.expMinusOne.Result(), false)
+	h.subnormalInv = newInverter(h.subnormal.gt)
+
+	leftAmount := &Bus{}
+	for i := 0; i < 5; i++ {
+		m := newMux2(h.zeros.count[i], h.expMinusOne.Result().wires[i], h.subnormal.gt, h.subnormalInv)
+		h.leftAmount = append(h.leftAmount, m)
+		leftAmount.wires = append(leftAmount.wires, m)
+	}
+
+	h.normLeft, _ = NewBarrelShifterFromBuses(ShiftLeft, result.Slice(1, 15), leftAmount)
+	h.rightSticky = newORGate(result.wires[13], result.wires[14])
+	shiftedRight := result.Slice(0, 13).Concat(NewBus(h.rightSticky))
+
+	h.carryInv = newInverter(carry)
+	normalized := &Bus{}
+	for i := 0; i < 14; i++ {
+		m := newMux2(h.normLeft.Result().wires[i], shiftedRight.wires[i], carry, h.carryInv)
+		h.normalized = append(h.normalized, m)
+		normalized.wires = append(normalized.wires, m)
+	}
+
+	h.expLeft, _ = NewSubtractorFromBuses(bigExp, leftAmount)
+	h.expRight = NewIncrementerFromBus(bigExp)
+
+	for i := 0; i < 5; i++ {
+		m := newMux2(h.expLeft.Difference().wires[i], h.expRight.Result().wires[i], carry, h.carryInv)
+		h.exponent = append(h.exponent, m)
+		h.expEncoded = append(h.expEncoded, newANDGate(m, normalized.wires[0]))
+	}
+
+	// round to nearest even: up when the guard bit is set, unless it's exactly halfway (no round or sticky bits) and already even
+	h.roundTail, _ = newORGateN(normalized.wires[12], normalized.wires[13], normalized.wires[10])
+	h.roundUp = newANDGate(normalized.wires[11], h.roundTail)
+
+	// the exponent and fraction are rounded together, so a fraction that rounds up past all 1s carries on into the exponent
+	packed := &Bus{}
+	for _, e := range h.expEncoded {
+		packed.wires = append(packed.wires, e)
+	}
+	packed = packed.Concat(normalized.Slice(1, 11))
+
+	h.rounded = newNamedAdderFromBuses("bits", packed, NewBus(make([]emitter, 15)...), h.roundUp)
+
+	h.buildSpecials()
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing adder settles on the new answer without being rebuilt
+func (h *HalfPrecisionAdder) UpdateInputs(bits1, bits2 string) error {
+	return h.updateSwitches("Floating point adder", 16, bits1, bits2)
+}
+
+// Sum returns the 16 bits of the floating point sum as a Bus that can be wired into other circuits
+func (h *HalfPrecisionAdder) Sum() *Bus {
+	return h.special.sumBus()
+}
+
+// Float is the sum as a Go float
+func (h *HalfPrecisionAdder) Float() float64 {
+	return Float16ToFloat64(uint16(h.Sum().Uint()))
+}
+
+func (h *HalfPrecisionAdder) ports() []namedPart {
+	var ports []namedPart
+
+	for i := 0; i < 16; i++ {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("bits1[%d]", i), h.a.bits.wires[i]},
+			namedPart{fmt.Sprintf("bits2[%d]", i), h.b.bits.wires[i]})
+	}
+
+	for i, w := range h.Sum().wires {
+		ports = append(ports, namedPart{fmt.Sprintf("sum[%d]", i), w})
+	}
+
+	return ports
+}
+
+func (h *HalfPrecisionAdder) parts() []namedPart {
+	parts := append(h.switchParts("bits"),
+		namedPart{"a", h.a},
+		namedPart{"b", h.b},
+		namedPart{"magnitude", h.magnitude},
+		namedPart{"swap", h.swap},
+		namedPart{"expDiff", h.expDiff},
+		namedPart{"align", h.align},
+		namedPart{"alignSticky", h.alignSticky},
+		namedPart{"effectiveSub", h.effectiveSub},
+		namedPart{"mantissas", h.mantissas},
+		namedPart{"zeros", h.zeros},
+		namedPart{"expMinusOne", h.expMinusOne},
+		namedPart{"subnormal", h.subnormal},
+		namedPart{"subnormalInv", h.subnormalInv})
+
+	for i, m := range h.leftAmount {
+		parts = append(parts, namedPart{fmt.Sprintf("leftAmount[%d]", i), m})
+	}
+
+	parts = append(parts,
+		namedPart{"normLeft", h.normLeft},
+		namedPart{"rightSticky", h.rightSticky},
+		namedPart{"carryInv", h.carryInv})
+
+	for i, m := range h.normalized {
+		parts = append(parts, namedPart{fmt.Sprintf("normalized[%d]", i), m})
+	}
+
+	parts = append(parts,
+		namedPart{"expLeft", h.expLeft},
+		namedPart{"expRight", h.expRight})
+
+	for i := range h.exponent {
+		parts = append(parts,
+			namedPart{fmt.Sprintf("exponent[%d]", i), h.exponent[i]},
+			namedPart{fmt.Sprintf("expEncoded[%d]", i), h.expEncoded[i]})
+	}
+
+	return append(parts,
+		namedPart{"roundTail", h.roundTail},
+		namedPart{"roundUp", h.roundUp},
+		namedPart{"rounded", h.rounded},
+		namedPart{"special", h.special})
+}
+
+func (h *HalfPrecisionAdder) outputs() []namedPart {
+	var parts []namedPart
+
+	for i, w := range h.Sum().wires {
+		parts = append(parts, namedPart{fmt.Sprintf("sum[%d]", i), w})
+	}
+
+	return parts
+}
+
+// String is the 16 bits of the sum
+func (h *HalfPrecisionAdder) String() string {
+	return h.Sum().String()
+}
+
+// Float16ToFloat64 turns the bits of a half precision number into a Go float (every half precision number has an exact float64)
+func Float16ToFloat64(bits uint16) float64 {
+	sign := 1.0
+	if bits&0x8000 != 0 {
+		sign = -1.0
+	}
+
+	exp := int(bits >> 10 & 0x1F)
+	frac := float64(bits & 0x3FF)
+
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(frac, -24)
+	case 0x1F:
+		if frac != 0 {
+			return math.NaN()
+		}
+		return math.Inf(int(sign))
+	}
+
+	return sign * math.Ldexp(1024+frac, exp-25)
+}
+
+// Float64ToFloat16 turns a Go float into the bits of the nearest half precision number (rounding to even when it's exactly halfway between two)
+func Float64ToFloat16(f float64) uint16 {
+	var sign uint16
+	if math.Signbit(f) {
+		sign = 0x8000
+	}
+
+	a := math.Abs(f)
+
+	switch {
+	case math.IsNaN(f):
+		return 0x7E00
+	case a >= 65520: // halfway between the biggest half precision number (65504) and 65536, which rounds up (to even) and out of range
+		return sign | 0x7C00
+	case a < math.Ldexp(1, -14): // subnormal, in steps of 2^-24 (rounding up to 1024 of them makes the smallest normal number)
+		return sign | uint16(math.RoundToEven(math.Ldexp(a, 24)))
+	}
+
+	_, e := math.Frexp(a) // a = m * 2^e with m in [0.5, 1)
+	e--
+
+	sig := math.RoundToEven(math.Ldexp(a, 10-e))
+	if sig == 2048 {
+		sig = 1024
+		e++
+	}
+
+	return sign | uint16(e+15)<<10 | uint16(sig-1024)
+}
@@ -20,10 +20,37 @@ func newANDGate(pin1, pin2 emitter) *andGate {
 	return g
 }
 
+func (g *andGate) ports() []namedPart {
+	return []namedPart{
+		{"pin1", g.relay1.bIn},
+		{"pin2", g.relay2.bIn},
+		{"out", g},
+	}
+}
+
+func (g *andGate) parts() []namedPart {
+	return []namedPart{
+		{"relay1", g.relay1},
+		{"relay2", g.relay2},
+	}
+}
+
+func (g *andGate) source() *pwrSource {
+	return g.relay2.closedOut.source()
+}
+
+func (g *andGate) WireUp(ch func(Signal)) {
+	g.relay2.closedOut.WireUp(ch)
+}
+
 func (g *andGate) Emitting() bool {
 	return g.relay2.closedOut.Emitting()
 }
 
+func (g *andGate) Signal() Signal {
+	return g.relay2.closedOut.Signal()
+}
+
 // OR
 // 0 0 0
 // 1 0 1
@@ -33,17 +60,38 @@ func (g *andGate) Emitting() bool {
 type orGate struct {
 	relay1 *relay
 	relay2 *relay
+	pwrSource
 }
 
 func newORGate(pin1, pin2 emitter) *orGate {
-	return &orGate{
-		newRelay(&Battery{}, pin1),
-		newRelay(&Battery{}, pin2),
+	g := &orGate{}
+
+	g.relay1 = newRelay(&Battery{}, pin1)
+	g.relay2 = newRelay(&Battery{}, pin2)
+
+	g.relay1.closedOut.WireUp(g.update)
+	g.relay2.closedOut.WireUp(g.update)
+
+	return g
+}
+
+func (g *orGate) ports() []namedPart {
+	return []namedPart{
+		{"pin1", g.relay1.bIn},
+		{"pin2", g.relay2.bIn},
+		{"out", g},
+	}
+}
+
+func (g *orGate) parts() []namedPart {
+	return []namedPart{
+		{"relay1", g.relay1},
+		{"relay2", g.relay2},
 	}
 }
 
-func (g *orGate) Emitting() bool {
-	return g.relay1.closedOut.Emitting() || g.relay2.closedOut.Emitting()
+func (g *orGate) update(Signal) {
+	g.transmit(orSignals(g.relay1.closedOut.Signal(), g.relay2.closedOut.Signal()))
 }
 
 // NAND
@@ -55,17 +103,38 @@ func (g *orGate) Emitting() bool {
 type nandGate struct {
 	relay1 *relay
 	relay2 *relay
+	pwrSource
 }
 
 func newNANDGate(pin1, pin2 emitter) *nandGate {
-	return &nandGate{
-		newRelay(&Battery{}, pin1),
-		newRelay(&Battery{}, pin2),
+	g := &nandGate{}
+
+	g.relay1 = newRelay(&Battery{}, pin1)
+	g.relay2 = newRelay(&Battery{}, pin2)
+
+	g.relay1.openOut.WireUp(g.update)
+	g.relay2.openOut.WireUp(g.update)
+
+	return g
+}
+
+func (g *nandGate) ports() []namedPart {
+	return []namedPart{
+		{"pin1", g.relay1.bIn},
+		{"pin2", g.relay2.bIn},
+		{"out", g},
+	}
+}
+
+func (g *nandGate) parts() []namedPart {
+	return []namedPart{
+		{"relay1", g.relay1},
+		{"relay2", g.relay2},
 	}
 }
 
-func (g *nandGate) Emitting() bool {
-	return g.relay1.openOut.Emitting() || g.relay2.openOut.Emitting()
+func (g *nandGate) update(Signal) {
+	g.transmit(orSignals(g.relay1.openOut.Signal(), g.relay2.openOut.Signal()))
 }
 
 // NOR
@@ -88,10 +157,37 @@ func newNORGate(pin1, pin2 emitter) *norGate {
 	return g
 }
 
+func (g *norGate) ports() []namedPart {
+	return []namedPart{
+		{"pin1", g.relay1.bIn},
+		{"pin2", g.relay2.bIn},
+		{"out", g},
+	}
+}
+
+func (g *norGate) parts() []namedPart {
+	return []namedPart{
+		{"relay1", g.relay1},
+		{"relay2", g.relay2},
+	}
+}
+
+func (g *norGate) source() *pwrSource {
+	return g.relay2.openOut.source()
+}
+
+func (g *norGate) WireUp(ch func(Signal)) {
+	g.relay2.openOut.WireUp(ch)
+}
+
 func (g *norGate) Emitting() bool {
 	return g.relay2.openOut.Emitting()
 }
 
+func (g *norGate) Signal() Signal {
+	return g.relay2.openOut.Signal()
+}
+
 // XOR
 // 0 0 0
 // 1 0 1
@@ -99,9 +195,9 @@ func (g *norGate) Emitting() bool {
 // 1 1 0
 
 type xorGate struct {
-	orGate   emitter
-	nandGate emitter
-	andGate  emitter
+	orGate   *orGate
+	nandGate *nandGate
+	andGate  *andGate
 }
 
 func newXORGate(pin1, pin2 emitter) *xorGate {
@@ -114,6 +210,34 @@ func newXORGate(pin1, pin2 emitter) *xorGate {
 	return g
 }
 
+func (g *xorGate) parts() []namedPart {
+	return []namedPart{
+		{"orGate", g.orGate},
+		{"nandGate", g.nandGate},
+		{"andGate", g.andGate},
+	}
+}
+
+func (g *xorGate) ports() []namedPart {
+	return []namedPart{
+		{"pin1", g.orGate.relay1.bIn},
+		{"pin2", g.orGate.relay2.bIn},
+		{"out", g},
+	}
+}
+
+func (g *xorGate) source() *pwrSource {
+	return g.andGate.source()
+}
+
+func (g *xorGate) WireUp(ch func(Signal)) {
+	g.andGate.WireUp(ch)
+}
+
 func (g *xorGate) Emitting() bool {
 	return g.andGate.Emitting()
 }
+
+func (g *xorGate) Signal() Signal {
+	return g.andGate.Signal()
+}
@@ -7,21 +7,50 @@ package circuit
 // 1 1 0
 
 type nandGate2 struct {
-	inverter emitter
+	andGate  *andGate
+	inverter *inverter
 }
 
 func newNANDGate2(pin1, pin2 emitter) *nandGate2 {
 	g := &nandGate2{}
 
-	g.inverter = newInverter(newANDGate(pin1, pin2))
+	g.andGate = newANDGate(pin1, pin2)
+	g.inverter = newInverter(g.andGate)
 
 	return g
 }
 
+func (g *nandGate2) parts() []namedPart {
+	return []namedPart{
+		{"andGate", g.andGate},
+		{"inverter", g.inverter},
+	}
+}
+
+func (g *nandGate2) ports() []namedPart {
+	return []namedPart{
+		{"pin1", portOf(g.andGate, "pin1")},
+		{"pin2", portOf(g.andGate, "pin2")},
+		{"out", g},
+	}
+}
+
+func (g *nandGate2) source() *pwrSource {
+	return g.inverter.source()
+}
+
+func (g *nandGate2) WireUp(ch func(Signal)) {
+	g.inverter.WireUp(ch)
+}
+
 func (g *nandGate2) Emitting() bool {
 	return g.inverter.Emitting()
 }
 
+func (g *nandGate2) Signal() Signal {
+	return g.inverter.Signal()
+}
+
 // NOR (using Inverter on an OR gate emit)
 // 0 0 1
 // 1 0 0
@@ -29,21 +58,50 @@ func (g *nandGate2) Emitting() bool {
 // 1 1 0
 
 type norGate2 struct {
-	inverter emitter
+	orGate   *orGate
+	inverter *inverter
 }
 
 func newNORGate2(pin1, pin2 emitter) *norGate2 {
 	g := &norGate2{}
 
-	g.inverter = newInverter(newORGate(pin1, pin2))
+	g.orGate = newORGate(pin1, pin2)
+	g.inverter = newInverter(g.orGate)
 
 	return g
 }
 
+func (g *norGate2) parts() []namedPart {
+	return []namedPart{
+		{"orGate", g.orGate},
+		{"inverter", g.inverter},
+	}
+}
+
+func (g *norGate2) ports() []namedPart {
+	return []namedPart{
+		{"pin1", portOf(g.orGate, "pin1")},
+		{"pin2", portOf(g.orGate, "pin2")},
+		{"out", g},
+	}
+}
+
+func (g *norGate2) source() *pwrSource {
+	return g.inverter.source()
+}
+
+func (g *norGate2) WireUp(ch func(Signal)) {
+	g.inverter.WireUp(ch)
+}
+
 func (g *norGate2) Emitting() bool {
 	return g.inverter.Emitting()
 }
 
+func (g *norGate2) Signal() Signal {
+	return g.inverter.Signal()
+}
+
 // XNOR (aka equivalence gate) (using Inverter on an XOR gate emit)
 // 0 0 1
 // 1 0 0
@@ -51,17 +109,46 @@ func (g *norGate2) Emitting() bool {
 // 1 1 1
 
 type xnorGate struct {
-	inverter emitter
+	xorGate  *xorGate
+	inverter *inverter
 }
 
 func newXNORGate(pin1, pin2 emitter) *xnorGate {
 	g := &xnorGate{}
 
-	g.inverter = newInverter(newXORGate(pin1, pin2))
+	g.xorGate = newXORGate(pin1, pin2)
+	g.inverter = newInverter(g.xorGate)
 
 	return g
 }
 
+func (g *xnorGate) parts() []namedPart {
+	return []namedPart{
+		{"xorGate", g.xorGate},
+		{"inverter", g.inverter},
+	}
+}
+
+func (g *xnorGate) ports() []namedPart {
+	return []namedPart{
+		{"pin1", portOf(g.xorGate, "pin1")},
+		{"pin2", portOf(g.xorGate, "pin2")},
+		{"out", g},
+	}
+}
+
+func (g *xnorGate) source() *pwrSource {
+	return g.inverter.source()
+}
+
+func (g *xnorGate) WireUp(ch func(Signal)) {
+	g.inverter.WireUp(ch)
+}
+
 func (g *xnorGate) Emitting() bool {
 	return g.inverter.Emitting()
 }
+
+func (g *xnorGate) Signal() Signal {
+	return g.inverter.Signal()
+}
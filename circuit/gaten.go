@@ -0,0 +1,359 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// N-input gates, built from relays the same way as their two-input versions (one relay per pin), so wide decoders, zero-detects and parity checks don't
+// need to be hand-chained out of two-input gates
+
+func validatePins(pins []emitter) error {
+	if len(pins) < 2 {
+		return errors.New(fmt.Sprintf("An N-input gate needs at least 2 pins, but got %d", len(pins)))
+	}
+
+	return nil
+}
+
+func relayPorts(relays []*relay, out emitter) []namedPart {
+	var ports []namedPart
+
+	for i, r := range relays {
+		ports = append(ports, namedPart{fmt.Sprintf("pin%d", i+1), r.bIn})
+	}
+
+	return append(ports, namedPart{"out", out})
+}
+
+func relayParts(relays []*relay) []namedPart {
+	var parts []namedPart
+
+	for i, r := range relays {
+		parts = append(parts, namedPart{fmt.Sprintf("relay%d", i+1), r})
+	}
+
+	return parts
+}
+
+// AND (N-input)
+// all pins 1           1
+// any pin 0            0
+//
+// Relays in series, each one powered by the closed contact of the one before it
+
+type andGateN struct {
+	relays []*relay
+}
+
+func newANDGateN(pins ...emitter) (*andGateN, error) {
+	if err := validatePins(pins); err != nil {
+		return nil, err
+	}
+
+	g := &andGateN{}
+
+	var pwr emitter = &Battery{}
+	for _, pin := range pins {
+		r := newRelay(pwr, pin)
+		g.relays = append(g.relays, r)
+		pwr = r.closedOut
+	}
+
+	return g, nil
+}
+
+func (g *andGateN) ports() []namedPart {
+	return relayPorts(g.relays, g)
+}
+
+func (g *andGateN) parts() []namedPart {
+	return relayParts(g.relays)
+}
+
+func (g *andGateN) out() *andContact {
+	return g.relays[len(g.relays)-1].closedOut
+}
+
+func (g *andGateN) source() *pwrSource {
+	return g.out().source()
+}
+
+func (g *andGateN) WireUp(ch func(Signal)) {
+	g.out().WireUp(ch)
+}
+
+func (g *andGateN) Emitting() bool {
+	return g.out().Emitting()
+}
+
+func (g *andGateN) Signal() Signal {
+	return g.out().Signal()
+}
+
+// OR (N-input)
+// any pin 1            1
+// all pins 0           0
+//
+// Relays in parallel, each one powered by its own battery
+
+type orGateN struct {
+	relays []*relay
+	pwrSource
+}
+
+func newORGateN(pins ...emitter) (*orGateN, error) {
+	if err := validatePins(pins); err != nil {
+		return nil, err
+	}
+
+	g := &orGateN{}
+
+	for _, pin := range pins {
+		g.relays = append(g.relays, newRelay(&Battery{}, pin))
+	}
+
+	for _, r := range g.relays {
+		r.closedOut.WireUp(g.update)
+	}
+
+	return g, nil
+}
+
+func (g *orGateN) ports() []namedPart {
+	return relayPorts(g.relays, g)
+}
+
+func (g *orGateN) parts() []namedPart {
+	return relayParts(g.relays)
+}
+
+func (g *orGateN) update(Signal) {
+	sigs := make([]Signal, len(g.relays))
+	for i, r := range g.relays {
+		sigs[i] = r.closedOut.Signal()
+	}
+
+	g.transmit(orSignals(sigs...))
+}
+
+// NAND (N-input)
+// all pins 1           0
+// any pin 0            1
+//
+// Relays in parallel, each one powered by its own battery, using the open contacts
+
+type nandGateN struct {
+	relays []*relay
+	pwrSource
+}
+
+func newNANDGateN(pins ...emitter) (*nandGateN, error) {
+	if err := validatePins(pins); err != nil {
+		return nil, err
+	}
+
+	g := &nandGateN{}
+
+	for _, pin := range pins {
+		g.relays = append(g.relays, newRelay(&Battery{}, pin))
+	}
+
+	for _, r := range g.relays {
+		r.openOut.WireUp(g.update)
+	}
+
+	return g, nil
+}
+
+func (g *nandGateN) ports() []namedPart {
+	return relayPorts(g.relays, g)
+}
+
+func (g *nandGateN) parts() []namedPart {
+	return relayParts(g.relays)
+}
+
+func (g *nandGateN) update(Signal) {
+	sigs := make([]Signal, len(g.relays))
+	for i, r := range g.relays {
+		sigs[i] = r.openOut.Signal()
+	}
+
+	g.transmit(orSignals(sigs...))
+}
+
+// NOR (N-input)
+// any pin 1            0
+// all pins 0           1
+//
+// Relays in series, each one powered by the open contact of the one before it
+
+type norGateN struct {
+	relays []*relay
+}
+
+func newNORGateN(pins ...emitter) (*norGateN, error) {
+	if err := validatePins(pins); err != nil {
+		return nil, err
+	}
+
+	g := &norGateN{}
+
+	var pwr emitter = &Battery{}
+	for _, pin := range pins {
+		r := newRelay(pwr, pin)
+		g.relays = append(g.relays, r)
+		pwr = r.openOut
+	}
+
+	return g, nil
+}
+
+func (g *norGateN) ports() []namedPart {
+	return relayPorts(g.relays, g)
+}
+
+func (g *norGateN) parts() []namedPart {
+	return relayParts(g.relays)
+}
+
+func (g *norGateN) out() *xContact {
+	return g.relays[len(g.relays)-1].openOut
+}
+
+func (g *norGateN) source() *pwrSource {
+	return g.out().source()
+}
+
+func (g *norGateN) WireUp(ch func(Signal)) {
+	g.out().WireUp(ch)
+}
+
+func (g *norGateN) Emitting() bool {
+	return g.out().Emitting()
+}
+
+func (g *norGateN) Signal() Signal {
+	return g.out().Signal()
+}
+
+// XOR (N-input, aka odd parity)
+// odd number of pins 1     1
+// even number of pins 1    0
+//
+// A chain of two-input XOR gates, each one adding the next pin into the parity so far
+
+type xorGateN struct {
+	xorGates []*xorGate
+}
+
+func newXORGateN(pins ...emitter) (*xorGateN, error) {
+	if err := validatePins(pins); err != nil {
+		return nil, err
+	}
+
+	g := &xorGateN{}
+
+	var parity emitter = pins[0]
+	for _, pin := range pins[1:] {
+		x := newXORGate(parity, pin)
+		g.xorGates = append(g.xorGates, x)
+		parity = x
+	}
+
+	return g, nil
+}
+
+func (g *xorGateN) ports() []namedPart {
+	ports := []namedPart{{"pin1", portOf(g.xorGates[0], "pin1")}}
+
+	for i, x := range g.xorGates {
+		ports = append(ports, namedPart{fmt.Sprintf("pin%d", i+2), portOf(x, "pin2")})
+	}
+
+	return append(ports, namedPart{"out", g})
+}
+
+func (g *xorGateN) parts() []namedPart {
+	var parts []namedPart
+
+	for i, x := range g.xorGates {
+		parts = append(parts, namedPart{fmt.Sprintf("xorGate%d", i+1), x})
+	}
+
+	return parts
+}
+
+func (g *xorGateN) out() *xorGate {
+	return g.xorGates[len(g.xorGates)-1]
+}
+
+func (g *xorGateN) source() *pwrSource {
+	return g.out().source()
+}
+
+func (g *xorGateN) WireUp(ch func(Signal)) {
+	g.out().WireUp(ch)
+}
+
+func (g *xorGateN) Emitting() bool {
+	return g.out().Emitting()
+}
+
+func (g *xorGateN) Signal() Signal {
+	return g.out().Signal()
+}
+
+// XNOR (N-input, aka even parity) (using Inverter on an N-input XOR gate emit)
+// even number of pins 1    1
+// odd number of pins 1     0
+
+type xnorGateN struct {
+	xorGate  *xorGateN
+	inverter *inverter
+}
+
+func newXNORGateN(pins ...emitter) (*xnorGateN, error) {
+	x, err := newXORGateN(pins...)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &xnorGateN{}
+
+	g.xorGate = x
+	g.inverter = newInverter(g.xorGate)
+
+	return g, nil
+}
+
+func (g *xnorGateN) ports() []namedPart {
+	ports := g.xorGate.ports()
+	ports[len(ports)-1] = namedPart{"out", g}
+
+	return ports
+}
+
+func (g *xnorGateN) parts() []namedPart {
+	return []namedPart{
+		{"xorGate", g.xorGate},
+		{"inverter", g.inverter},
+	}
+}
+
+func (g *xnorGateN) source() *pwrSource {
+	return g.inverter.source()
+}
+
+func (g *xnorGateN) WireUp(ch func(Signal)) {
+	g.inverter.WireUp(ch)
+}
+
+func (g *xnorGateN) Emitting() bool {
+	return g.inverter.Emitting()
+}
+
+func (g *xnorGateN) Signal() Signal {
+	return g.inverter.Signal()
+}
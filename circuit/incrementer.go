@@ -0,0 +1,429 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// N-bit Incrementer
+// Adds 1 to a number of any width.  Adding just a 1 never needs more than a half adder per bit, the 1 going in as the carry of the rightmost one
+//    0111
+// +     1
+// =  1000   overflow (7 + 1 doesn't fit in 4 bits read signed)
+
+type Incrementer struct {
+	bitsSwitches *Bus // only when built from a bit string, otherwise the inputs belong to whatever drives them
+	halfAdders   []*halfAdder
+	carryOut     emitter
+	overflow     *xorGate
+}
+
+// NewIncrementer builds an incrementer of any width (at least 1 bit) from a string of that many 0s and 1s
+func NewIncrementer(width int, bits string) (*Incrementer, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Incrementer width must be at least 1, but got ", width))
+	}
+
+	bits, err := parseOperand(bits, width)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBits(width, bits, bits); err != nil {
+		return nil, err
+	}
+
+	inc := &Incrementer{}
+
+	inc.bitsSwitches = newSwitchBusFromBits(bits)
+
+	inc.build(inc.bitsSwitches)
+
+	return inc, nil
+}
+
+// NewIncrementerFromBus builds an incrementer wired directly to a Bus (e.g. the output of a register), so it follows any change on it
+func NewIncrementerFromBus(bus *Bus) *Incrementer {
+	inc := &Incrementer{}
+
+	inc.build(bus)
+
+	return inc
+}
+
+func (inc *Incrementer) build(bus *Bus) {
+	last := bus.Width() - 1
+	inc.halfAdders = make([]*halfAdder, bus.Width())
+
+	var one emitter = &Battery{}
+	carry := one
+	for i := last; i >= 0; i-- {
+		inc.halfAdders[i] = newHalfAdder(bus.wires[i], carry)
+		carry = inc.halfAdders[i].carry
+	}
+	inc.carryOut = carry
+
+	msbCarryIn := one // the carry into the leftmost bit, which for a 1-bit incrementer is the 1 itself
+	if bus.Width() > 1 {
+		msbCarryIn = inc.halfAdders[1].carry
+	}
+	inc.overflow = newXORGate(msbCarryIn, inc.carryOut)
+}
+
+// Width is how many bits the incrementer works on
+func (inc *Incrementer) Width() int {
+	return len(inc.halfAdders)
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing incrementer settles on the new answer without being rebuilt
+func (inc *Incrementer) UpdateInputs(bits string) error {
+	if inc.bitsSwitches == nil {
+		return errors.New("Incrementer inputs are driven by a Bus, not switches, so they cannot be updated")
+	}
+
+	bits, err := parseOperand(bits, inc.Width())
+	if err != nil {
+		return err
+	}
+
+	if err := validateBits(inc.Width(), bits, bits); err != nil {
+		return err
+	}
+
+	Simultaneously(func() {
+		inc.bitsSwitches.setBits(bits)
+	})
+
+	return nil
+}
+
+// Result returns the incremented bits as a Bus that can be wired into other circuits
+func (inc *Incrementer) Result() *Bus {
+	b := &Bus{}
+
+	for _, h := range inc.halfAdders {
+		b.wires = append(b.wires, h.sum)
+	}
+
+	return b
+}
+
+// CarryOut returns the carry out of the leftmost bit (the number wrapped around from all 1s to all 0s) so it can be wired into other circuits
+func (inc *Incrementer) CarryOut() emitter {
+	return inc.carryOut
+}
+
+// Overflow is true when the answer (read signed) didn't fit, i.e. the largest positive number wrapped around to the most negative
+func (inc *Incrementer) Overflow() bool {
+	return inc.overflow.Emitting()
+}
+
+func (inc *Incrementer) ports() []namedPart {
+	var ports []namedPart
+
+	for i, h := range inc.halfAdders {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("bits[%d]", i), portOf(h, "pin1")},
+			namedPart{fmt.Sprintf("result[%d]", i), h.sum})
+	}
+
+	return append(ports,
+		namedPart{"carryOut", inc.carryOut},
+		namedPart{"overflow", inc.overflow})
+}
+
+func (inc *Incrementer) parts() []namedPart {
+	var parts []namedPart
+
+	if inc.bitsSwitches != nil {
+		for i, w := range inc.bitsSwitches.wires {
+			parts = append(parts, namedPart{fmt.Sprintf("bitsSwitches[%d]", i), w})
+		}
+	}
+
+	for i, h := range inc.halfAdders {
+		parts = append(parts, namedPart{fmt.Sprintf("halfAdders[%d]", i), h})
+	}
+
+	return append(parts, namedPart{"overflow", inc.overflow})
+}
+
+func (inc *Incrementer) outputs() []namedPart {
+	parts := []namedPart{{"carryOut", inc.carryOut}}
+
+	for i, h := range inc.halfAdders {
+		parts = append(parts, namedPart{fmt.Sprintf("halfAdders[%d].sum", i), h.sum})
+	}
+
+	return append(parts, namedPart{"overflow", inc.overflow})
+}
+
+// String is the incremented bits (without the carry out)
+func (inc *Incrementer) String() string {
+	return inc.Result().String()
+}
+
+// N-bit Decrementer
+// Subtracts 1 from a number of any width, by complementing it, incrementing that, and complementing it back (since NOT (NOT A + 1) = A - 1)
+//    1000
+// -     1
+// =  0111   overflow (-8 - 1 doesn't fit in 4 bits read signed)
+
+type Decrementer struct {
+	bitsSwitches *Bus // only when built from a bit string, otherwise the inputs belong to whatever drives them
+	invert       *onesComplementer
+	incrementer  *Incrementer
+	revert       *onesComplementer
+}
+
+// NewDecrementer builds a decrementer of any width (at least 1 bit) from a string of that many 0s and 1s
+func NewDecrementer(width int, bits string) (*Decrementer, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Decrementer width must be at least 1, but got ", width))
+	}
+
+	bits, err := parseOperand(bits, width)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBits(width, bits, bits); err != nil {
+		return nil, err
+	}
+
+	dec := &Decrementer{}
+
+	dec.bitsSwitches = newSwitchBusFromBits(bits)
+
+	dec.build(dec.bitsSwitches)
+
+	return dec, nil
+}
+
+// NewDecrementerFromBus builds a decrementer wired directly to a Bus (e.g. the output of a register), so it follows any change on it
+func NewDecrementerFromBus(bus *Bus) *Decrementer {
+	dec := &Decrementer{}
+
+	dec.build(bus)
+
+	return dec
+}
+
+func (dec *Decrementer) build(bus *Bus) {
+	dec.invert = NewOnesComplementerFromBus(bus, &Battery{})
+	dec.incrementer = NewIncrementerFromBus(dec.invert.ComplementBus())
+	dec.revert = NewOnesComplementerFromBus(dec.incrementer.Result(), &Battery{})
+}
+
+// Width is how many bits the decrementer works on
+func (dec *Decrementer) Width() int {
+	return dec.incrementer.Width()
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing decrementer settles on the new answer without being rebuilt
+func (dec *Decrementer) UpdateInputs(bits string) error {
+	if dec.bitsSwitches == nil {
+		return errors.New("Decrementer inputs are driven by a Bus, not switches, so they cannot be updated")
+	}
+
+	bits, err := parseOperand(bits, dec.Width())
+	if err != nil {
+		return err
+	}
+
+	if err := validateBits(dec.Width(), bits, bits); err != nil {
+		return err
+	}
+
+	Simultaneously(func() {
+		dec.bitsSwitches.setBits(bits)
+	})
+
+	return nil
+}
+
+// Result returns the decremented bits as a Bus that can be wired into other circuits
+func (dec *Decrementer) Result() *Bus {
+	return dec.revert.ComplementBus()
+}
+
+// BorrowOut returns the borrow out of the leftmost bit (the number wrapped around from all 0s to all 1s) so it can be wired into other circuits
+func (dec *Decrementer) BorrowOut() emitter {
+	return dec.incrementer.carryOut
+}
+
+// Overflow is true when the answer (read signed) didn't fit, i.e. the most negative number wrapped around to the largest positive
+func (dec *Decrementer) Overflow() bool {
+	return dec.incrementer.Overflow()
+}
+
+func (dec *Decrementer) ports() []namedPart {
+	var ports []namedPart
+
+	for i := 0; i < dec.Width(); i++ {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("bits[%d]", i), portOf(dec.invert, fmt.Sprintf("bits[%d]", i))},
+			namedPart{fmt.Sprintf("result[%d]", i), portOf(dec.revert, fmt.Sprintf("complement[%d]", i))})
+	}
+
+	return append(ports,
+		namedPart{"borrowOut", dec.BorrowOut()},
+		namedPart{"overflow", dec.incrementer.overflow})
+}
+
+func (dec *Decrementer) parts() []namedPart {
+	var parts []namedPart
+
+	if dec.bitsSwitches != nil {
+		for i, w := range dec.bitsSwitches.wires {
+			parts = append(parts, namedPart{fmt.Sprintf("bitsSwitches[%d]", i), w})
+		}
+	}
+
+	return append(parts,
+		namedPart{"invert", dec.invert},
+		namedPart{"incrementer", dec.incrementer},
+		namedPart{"revert", dec.revert})
+}
+
+func (dec *Decrementer) outputs() []namedPart {
+	return append(prefixParts("revert", dec.revert.outputs()),
+		namedPart{"borrowOut", dec.BorrowOut()},
+		namedPart{"overflow", dec.incrementer.overflow})
+}
+
+// String is the decremented bits (without the borrow out)
+func (dec *Decrementer) String() string {
+	return dec.Result().String()
+}
+
+// N-bit Negator
+// Flips the sign of a (two's complement) number of any width, by complementing it and adding 1
+//    0011   (3)
+// =  1101   (-3)
+//
+//    1000   (-8)
+// =  1000   overflow (8 doesn't fit in 4 bits read signed)
+
+type Negator struct {
+	bitsSwitches *Bus // only when built from a bit string, otherwise the inputs belong to whatever drives them
+	invert       *onesComplementer
+	incrementer  *Incrementer
+}
+
+// NewNegator builds a negator of any width (at least 1 bit) from a string of that many 0s and 1s
+func NewNegator(width int, bits string) (*Negator, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Negator width must be at least 1, but got ", width))
+	}
+
+	bits, err := parseOperand(bits, width)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBits(width, bits, bits); err != nil {
+		return nil, err
+	}
+
+	n := &Negator{}
+
+	n.bitsSwitches = newSwitchBusFromBits(bits)
+
+	n.build(n.bitsSwitches)
+
+	return n, nil
+}
+
+// NewNegatorFromBus builds a negator wired directly to a Bus (e.g. the Sum of an adder), so it follows any change on it
+func NewNegatorFromBus(bus *Bus) *Negator {
+	n := &Negator{}
+
+	n.build(bus)
+
+	return n
+}
+
+func (n *Negator) build(bus *Bus) {
+	n.invert = NewOnesComplementerFromBus(bus, &Battery{})
+	n.incrementer = NewIncrementerFromBus(n.invert.ComplementBus())
+}
+
+// Width is how many bits the negator works on
+func (n *Negator) Width() int {
+	return n.incrementer.Width()
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing negator settles on the new answer without being rebuilt
+func (n *Negator) UpdateInputs(bits string) error {
+	if n.bitsSwitches == nil {
+		return errors.New("Negator inputs are driven by a Bus, not switches, so they cannot be updated")
+	}
+
+	bits, err := parseOperand(bits, n.Width())
+	if err != nil {
+		return err
+	}
+
+	if err := validateBits(n.Width(), bits, bits); err != nil {
+		return err
+	}
+
+	Simultaneously(func() {
+		n.bitsSwitches.setBits(bits)
+	})
+
+	return nil
+}
+
+// Result returns the negated bits as a Bus that can be wired into other circuits
+func (n *Negator) Result() *Bus {
+	return n.incrementer.Result()
+}
+
+// CarryOut returns the carry out of the inner incrementer, which is only ever 1 when negating 0
+func (n *Negator) CarryOut() emitter {
+	return n.incrementer.carryOut
+}
+
+// Overflow is true when the answer (read signed) didn't fit, which only happens when negating the most negative number
+func (n *Negator) Overflow() bool {
+	return n.incrementer.Overflow()
+}
+
+func (n *Negator) ports() []namedPart {
+	var ports []namedPart
+
+	for i := 0; i < n.Width(); i++ {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("bits[%d]", i), portOf(n.invert, fmt.Sprintf("bits[%d]", i))},
+			namedPart{fmt.Sprintf("result[%d]", i), n.incrementer.halfAdders[i].sum})
+	}
+
+	return append(ports,
+		namedPart{"carryOut", n.CarryOut()},
+		namedPart{"overflow", n.incrementer.overflow})
+}
+
+func (n *Negator) parts() []namedPart {
+	var parts []namedPart
+
+	if n.bitsSwitches != nil {
+		for i, w := range n.bitsSwitches.wires {
+			parts = append(parts, namedPart{fmt.Sprintf("bitsSwitches[%d]", i), w})
+		}
+	}
+
+	return append(parts,
+		namedPart{"invert", n.invert},
+		namedPart{"incrementer", n.incrementer})
+}
+
+func (n *Negator) outputs() []namedPart {
+	return prefixParts("incrementer", n.incrementer.outputs())
+}
+
+// String is the negated bits (without the carry out)
+func (n *Negator) String() string {
+	return n.Result().String()
+}
@@ -2,16 +2,43 @@ package circuit
 
 type inverter struct {
 	in      emitter
-	openOut emitter
+	openOut *xContact
 }
 
 func newInverter(pin emitter) *inverter {
-	return &inverter{
+	i := &inverter{
 		pin,
 		newXContact(&Battery{}, pin),
 	}
+
+	i.openOut.delay = DefaultRelayDelay // an inverter is just a relay wired to its open contact
+
+	return i
+}
+
+func (i *inverter) ports() []namedPart {
+	return []namedPart{
+		{"in", i.in},
+		{"out", i.openOut},
+	}
+}
+
+func (i *inverter) parts() []namedPart {
+	return []namedPart{{"openOut", i.openOut}}
+}
+
+func (i *inverter) source() *pwrSource {
+	return i.openOut.source()
+}
+
+func (i *inverter) WireUp(ch func(Signal)) {
+	i.openOut.WireUp(ch)
 }
 
 func (i *inverter) Emitting() bool {
 	return i.openOut.Emitting()
 }
+
+func (i *inverter) Signal() Signal {
+	return i.openOut.Signal()
+}
@@ -8,53 +8,69 @@ package circuit
 // X 0     q  !q  (data doesn't matter, no clock high to trigger a store-it action)
 
 type levTrigDLatch struct {
-	dataIn emitter
-	clkIn  emitter
-	rs     *rsFlipFlop
-	rAnd   *andGate
-	sAnd   *andGate
+	dataIn  *jumper
+	clkIn   *jumper
+	rs      *rsFlipFlop
+	dataInv *inverter
+	rAnd    *andGate
+	sAnd    *andGate
 }
 
 func newLtDLatch(dataIn, clkIn emitter) (*levTrigDLatch, error) {
-	l := &levTrigDLatch{}
-
-	l.updateInputs(dataIn, clkIn)
+	l := &levTrigDLatch{
+		dataIn: newJumper(Low),
+		clkIn:  newJumper(Low),
+	}
 
-	l.rs, _ = newRSFlipFLop(nil, nil) // make defaulted inner flipflop. setupComponents will set it up fully
+	l.dataInv = newInverter(l.dataIn)
+	l.rAnd = newANDGate(l.dataInv, l.clkIn)
+	l.sAnd = newANDGate(l.dataIn, l.clkIn)
 
-	err := l.setupComponents()
+	rs, err := newRSFlipFLop(l.rAnd, l.sAnd)
 	if err != nil {
 		return nil, err
 	}
+	l.rs = rs
+
+	l.updateInputs(dataIn, clkIn)
 
 	return l, nil
 }
 
 func (l *levTrigDLatch) updateInputs(dataIn, clkIn emitter) {
-	l.dataIn = dataIn
-	l.clkIn = clkIn
+	Simultaneously(func() {
+		l.dataIn.connect(dataIn)
+		l.clkIn.connect(clkIn)
+	})
 }
 
-func (l *levTrigDLatch) setupComponents() error {
-	l.rAnd = newANDGate(newInverter(l.dataIn), l.clkIn)
-	l.sAnd = newANDGate(l.dataIn, l.clkIn)
-
-	// pass along the new input states to the inner flipflop
-	err := l.rs.updateInputs(l.rAnd, l.sAnd)
-	if err != nil {
-		return err
+func (l *levTrigDLatch) ports() []namedPart {
+	return []namedPart{
+		{"dataIn", l.dataIn.in},
+		{"clkIn", l.clkIn.in},
+		{"q", portOf(l.rs, "q")},
+		{"qBar", portOf(l.rs, "qBar")},
 	}
+}
 
-	return nil
+func (l *levTrigDLatch) parts() []namedPart {
+	return []namedPart{
+		{"dataInv", l.dataInv},
+		{"rAnd", l.rAnd},
+		{"sAnd", l.sAnd},
+		{"rs", l.rs},
+	}
 }
 
-func (l *levTrigDLatch) qEmitting() (bool, error) {
+func (l *levTrigDLatch) qSignal() (Signal, error) {
+	return l.rs.qSignal()
+}
 
-	err := l.setupComponents()
-	if err != nil {
-		return false, err
-	}
+func (l *levTrigDLatch) qBarSignal() (Signal, error) {
+	return l.rs.qBarSignal()
+}
 
+func (l *levTrigDLatch) qEmitting() (bool, error) {
 	if qEmitting, err := l.rs.qEmitting(); err != nil {
 		return qEmitting, err
 	} else {
@@ -0,0 +1,206 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Carry-Lookahead Adder
+// Instead of waiting on every carry to ripple through every bit, each bit works out up front whether it generates a carry (A and B) or propagates one
+// (A xor B).  The carry out of a bit is then a single OR of ANDs of those, looking all the way back to the start of its block:
+//
+//	c1 = g0 + p0.c0
+//	c2 = g1 + p1.g0 + p1.p0.c0
+//	c3 = g2 + p2.g1 + p2.p1.g0 + p2.p1.p0.c0
+//	c4 = g3 + p3.g2 + p3.p2.g1 + p3.p2.p1.g0 + p3.p2.p1.p0.c0
+//
+// Looking back further than a block of lookaheadBlock bits makes the gates impractically wide, so carries still ripple from block to block (but only
+// one OR of ANDs per block instead of two gates per bit)
+
+const lookaheadBlock = 4
+
+type CarryLookaheadAdder struct {
+	switchInputs
+	bits1      *Bus
+	bits2      *Bus
+	propagates []*xorGate
+	generates  []*andGate
+	carryTerms [][]*andGateN // the ANDs ORed together to make carries[i]
+	carries    []*orGateN    // carries[i] is the carry out of bit i
+	sums       []*xorGate
+	carryIn    emitter
+	carryOut   emitter
+}
+
+// NewCarryLookaheadAdder builds a carry-lookahead adder of any width (at least 1 bit) from two strings of that many 0s and 1s
+func NewCarryLookaheadAdder(width int, bits1, bits2 string, carryIn emitter) (*CarryLookaheadAdder, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Adder width must be at least 1, but got ", width))
+	}
+
+	bits1, bits2, err := parseOperandPair(width, bits1, bits2)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBits(width, bits1, bits2); err != nil {
+		return nil, err
+	}
+
+	a := &CarryLookaheadAdder{}
+
+	a.bits1Switches = newSwitchBusFromBits(bits1)
+	a.bits2Switches = newSwitchBusFromBits(bits2)
+
+	a.build(a.bits1Switches, a.bits2Switches, carryIn)
+
+	return a, nil
+}
+
+// NewCarryLookaheadAdderFromBuses builds a carry-lookahead adder as wide as the two Buses, wired directly to them, so it follows any change on them
+func NewCarryLookaheadAdderFromBuses(bus1, bus2 *Bus, carryIn emitter) (*CarryLookaheadAdder, error) {
+	if bus1.Width() != bus2.Width() {
+		return nil, errors.New(fmt.Sprintf("Inputs are not the same width: %d and %d bits", bus1.Width(), bus2.Width()))
+	}
+
+	if bus1.Width() < 1 {
+		return nil, errors.New(fmt.Sprint("Adder width must be at least 1, but got ", bus1.Width()))
+	}
+
+	a := &CarryLookaheadAdder{}
+
+	a.build(bus1, bus2, carryIn)
+
+	return a, nil
+}
+
+func (a *CarryLookaheadAdder) build(bus1, bus2 *Bus, carryIn emitter) {
+	width := bus1.Width()
+
+	a.bits1 = bus1
+	a.bits2 = bus2
+	a.carryIn = carryIn
+	a.propagates = make([]*xorGate, width)
+	a.generates = make([]*andGate, width)
+	a.carryTerms = make([][]*andGateN, width)
+	a.carries = make([]*orGateN, width)
+	a.sums = make([]*xorGate, width)
+
+	for i := 0; i < width; i++ {
+		a.propagates[i] = newXORGate(bus1.wires[i], bus2.wires[i])
+		a.generates[i] = newANDGate(bus1.wires[i], bus2.wires[i])
+	}
+
+	// bits are numbered from the left (like the Bus), so the rightmost bit (width-1) is where the carries start from
+	for i := width - 1; i >= 0; i-- {
+		start := i + (width-1-i)%lookaheadBlock // rightmost bit of the block this bit is in
+		blockCarryIn := a.carryInto(start)
+
+		terms := []emitter{a.generates[i]}
+		for j := i + 1; j <= start+1; j++ {
+			pins := []emitter{}
+			for k := i; k < j; k++ {
+				pins = append(pins, a.propagates[k])
+			}
+
+			if j <= start {
+				pins = append(pins, a.generates[j])
+			} else {
+				pins = append(pins, blockCarryIn)
+			}
+
+			term, _ := newANDGateN(pins...) // always at least one propagate and one generate (or carry)
+			a.carryTerms[i] = append(a.carryTerms[i], term)
+			terms = append(terms, term)
+		}
+
+		a.carries[i], _ = newORGateN(terms...) // always the generate plus at least one term
+		a.sums[i] = newXORGate(a.propagates[i], a.carryInto(i))
+	}
+
+	a.carryOut = a.carries[0]
+}
+
+// carryInto is the carry into bit i, which is the carry out of its neighbor to the right (or the carry in of the whole adder for the rightmost bit)
+func (a *CarryLookaheadAdder) carryInto(i int) emitter {
+	if i == len(a.carries)-1 {
+		return a.carryIn
+	}
+
+	return a.carries[i+1]
+}
+
+// Width is how many bits the adder sums (not counting the carry)
+func (a *CarryLookaheadAdder) Width() int {
+	return len(a.sums)
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing adder settles on the new answer without being rebuilt
+func (a *CarryLookaheadAdder) UpdateInputs(bits1, bits2 string) error {
+	return a.updateSwitches("Adder", a.Width(), bits1, bits2)
+}
+
+// Sum returns the sum bits as a Bus that can be wired into other circuits
+func (a *CarryLookaheadAdder) Sum() *Bus {
+	b := &Bus{}
+
+	for _, s := range a.sums {
+		b.wires = append(b.wires, s)
+	}
+
+	return b
+}
+
+// CarryOut returns the carry out of the leftmost bit so it can be wired into other circuits
+func (a *CarryLookaheadAdder) CarryOut() emitter {
+	return a.carryOut
+}
+
+func (a *CarryLookaheadAdder) ports() []namedPart {
+	var ports []namedPart
+
+	for i, s := range a.sums {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("bits1[%d]", i), a.bits1.wires[i]},
+			namedPart{fmt.Sprintf("bits2[%d]", i), a.bits2.wires[i]},
+			namedPart{fmt.Sprintf("sum[%d]", i), s})
+	}
+
+	return append(ports,
+		namedPart{"carryIn", a.carryIn},
+		namedPart{"carryOut", a.carryOut})
+}
+
+func (a *CarryLookaheadAdder) parts() []namedPart {
+	parts := a.switchParts("bits")
+
+	for i := range a.sums {
+		parts = append(parts,
+			namedPart{fmt.Sprintf("propagates[%d]", i), a.propagates[i]},
+			namedPart{fmt.Sprintf("generates[%d]", i), a.generates[i]})
+
+		for j, term := range a.carryTerms[i] {
+			parts = append(parts, namedPart{fmt.Sprintf("carryTerms[%d][%d]", i, j), term})
+		}
+
+		parts = append(parts,
+			namedPart{fmt.Sprintf("carries[%d]", i), a.carries[i]},
+			namedPart{fmt.Sprintf("sums[%d]", i), a.sums[i]})
+	}
+
+	return parts
+}
+
+func (a *CarryLookaheadAdder) outputs() []namedPart {
+	parts := []namedPart{{"carryOut", a.carryOut}}
+
+	for i, s := range a.sums {
+		parts = append(parts, namedPart{fmt.Sprintf("sums[%d]", i), s})
+	}
+
+	return parts
+}
+
+func (a *CarryLookaheadAdder) String() string {
+	return sumString(a)
+}
@@ -0,0 +1,285 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Array Multiplier
+// Just like long multiplication on paper, every bit of the first input is ANDed with every bit of the second (the partial products), then each row of
+// partial products is added, shifted one more place to the left, onto the running total by its own adder.  N x N bits make a 2N-bit product
+//        1011
+// x      1101
+//        ----
+//        1011
+//       0000
+//      1011
+// +   1011
+// = 10001111
+//
+// The signed (two's complement) variant is Baugh-Wooley: the partial products mixing one sign bit with one ordinary bit are NANDed instead of ANDed,
+// and 1s are added at the columns N and 2N-1 (the first as the carry into the top of the first adder row, the second by inverting the top product bit)
+
+type Multiplier struct {
+	switchInputs
+	signed   bool
+	bits1    *Bus
+	bits2    *Bus
+	partials [][]emitter // partials[i][j] is bit j of the first input times bit i of the second, both counted from the right
+	rows     []*Adder    // rows[i-1] adds the partials of row i onto the running total
+	signFix  *inverter   // only when signed
+	product  *Bus
+}
+
+// NewMultiplier builds an unsigned multiplier of any width (at least 1 bit) from two strings of that many 0s and 1s
+func NewMultiplier(width int, bits1, bits2 string) (*Multiplier, error) {
+	return newMultiplier(width, bits1, bits2, false)
+}
+
+// NewSignedMultiplier builds a two's complement multiplier of any width (at least 1 bit) from two strings of that many 0s and 1s
+func NewSignedMultiplier(width int, bits1, bits2 string) (*Multiplier, error) {
+	return newMultiplier(width, bits1, bits2, true)
+}
+
+func newMultiplier(width int, bits1, bits2 string, signed bool) (*Multiplier, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Multiplier width must be at least 1, but got ", width))
+	}
+
+	bits1, bits2, err := parseOperandPair(width, bits1, bits2)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBits(width, bits1, bits2); err != nil {
+		return nil, err
+	}
+
+	m := &Multiplier{}
+
+	m.bits1Switches = newSwitchBusFromBits(bits1)
+	m.bits2Switches = newSwitchBusFromBits(bits2)
+
+	m.build(m.bits1Switches, m.bits2Switches, signed)
+
+	return m, nil
+}
+
+// NewMultiplierFromBuses builds an unsigned multiplier as wide as the two Buses, wired directly to them, so it follows any change on them
+func NewMultiplierFromBuses(bus1, bus2 *Bus) (*Multiplier, error) {
+	return newMultiplierFromBuses(bus1, bus2, false)
+}
+
+// NewSignedMultiplierFromBuses builds a two's complement multiplier as wide as the two Buses, wired directly to them, so it follows any change on them
+func NewSignedMultiplierFromBuses(bus1, bus2 *Bus) (*Multiplier, error) {
+	return newMultiplierFromBuses(bus1, bus2, true)
+}
+
+func newMultiplierFromBuses(bus1, bus2 *Bus, signed bool) (*Multiplier, error) {
+	if bus1.Width() != bus2.Width() {
+		return nil, errors.New(fmt.Sprintf("Inputs are not the same width: %d and %d bits", bus1.Width(), bus2.Width()))
+	}
+
+	if bus1.Width() < 1 {
+		return nil, errors.New(fmt.Sprint("Multiplier width must be at least 1, but got ", bus1.Width()))
+	}
+
+	m := &Multiplier{}
+
+	m.build(bus1, bus2, signed)
+
+	return m, nil
+}
+
+func (m *Multiplier) build(bus1, bus2 *Bus, signed bool) {
+	n := bus1.Width()
+	sign := n - 1
+
+	m.signed = signed
+	m.bits1 = bus1
+	m.bits2 = bus2
+
+	// buses run from the left, but the columns of a long multiplication are easier counted from the right
+	a := func(j int) emitter { return bus1.wires[n-1-j] }
+	b := func(i int) emitter { return bus2.wires[n-1-i] }
+
+	m.partials = make([][]emitter, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if signed && (i == sign) != (j == sign) {
+				m.partials[i] = append(m.partials[i], newNANDGate(a(j), b(i)))
+			} else {
+				m.partials[i] = append(m.partials[i], newANDGate(a(j), b(i)))
+			}
+		}
+	}
+
+	low := make([]emitter, n) // the product bits that are done once each row is added, counted from the right
+	total := m.partials[0]    // the running total still to be added onto, counted from the right
+	low[0] = total[0]
+
+	var carry emitter // the carry out of the last row, which becomes the top bit of the running total
+	if signed {
+		carry = &Battery{} // the 1 added at column N
+	}
+
+	for i := 1; i < n; i++ {
+		x := []emitter{carry}
+		y := []emitter{}
+		for k := n - 1; k >= 0; k-- {
+			if k > 0 {
+				x = append(x, total[k])
+			}
+			y = append(y, m.partials[i][k])
+		}
+
+		row := newNamedAdderFromBuses("bits", NewBus(x...), NewBus(y...), nil)
+		m.rows = append(m.rows, row)
+
+		total = make([]emitter, n)
+		for k := 0; k < n; k++ {
+			total[k] = row.fullAdders[n-1-k].sum
+		}
+
+		low[i] = total[0]
+		carry = row.carryOut
+	}
+
+	top := carry
+	if signed {
+		m.signFix = newInverter(carry) // the 1 added at column 2N-1
+		top = m.signFix
+	}
+
+	m.product = NewBus(top)
+	for k := n - 1; k > 0; k-- {
+		m.product.wires = append(m.product.wires, total[k])
+	}
+	for k := n - 1; k >= 0; k-- {
+		m.product.wires = append(m.product.wires, low[k])
+	}
+}
+
+// Width is how many bits each input has (the product has twice as many)
+func (m *Multiplier) Width() int {
+	return m.bits1.Width()
+}
+
+// Signed is true when the inputs and product are read as two's complement
+func (m *Multiplier) Signed() bool {
+	return m.signed
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing multiplier settles on the new product without being rebuilt
+func (m *Multiplier) UpdateInputs(bits1, bits2 string) error {
+	return m.updateSwitches("Multiplier", m.Width(), bits1, bits2)
+}
+
+// Product returns the 2N product bits as a Bus that can be wired into other circuits
+func (m *Multiplier) Product() *Bus {
+	return NewBus(m.product.wires...)
+}
+
+func (m *Multiplier) ports() []namedPart {
+	var ports []namedPart
+
+	for i := range m.bits1.wires {
+		ports = append(ports,
+			namedPart{fmt.Sprintf("bits1[%d]", i), m.bits1.wires[i]},
+			namedPart{fmt.Sprintf("bits2[%d]", i), m.bits2.wires[i]})
+	}
+
+	for i, p := range m.product.wires {
+		ports = append(ports, namedPart{fmt.Sprintf("product[%d]", i), p})
+	}
+
+	return ports
+}
+
+func (m *Multiplier) parts() []namedPart {
+	parts := m.switchParts("bits")
+
+	for i, row := range m.partials {
+		for j, p := range row {
+			parts = append(parts, namedPart{fmt.Sprintf("partials[%d][%d]", i, j), p})
+		}
+	}
+
+	for i, row := range m.rows {
+		parts = append(parts, namedPart{fmt.Sprintf("rows[%d]", i), row})
+	}
+
+	if m.signFix != nil {
+		parts = append(parts, namedPart{"signFix", m.signFix})
+	}
+
+	return parts
+}
+
+func (m *Multiplier) outputs() []namedPart {
+	var parts []namedPart
+
+	for i, p := range m.product.wires {
+		if p != nil {
+			parts = append(parts, namedPart{fmt.Sprintf("product[%d]", i), p})
+		}
+	}
+
+	return parts
+}
+
+// String is the 2N-bit product
+func (m *Multiplier) String() string {
+	return m.product.String()
+}
+
+// 8-bit Multiplier
+//    10011101
+// x  11010110
+// = 1000001100111110
+
+type EightBitMultiplier struct {
+	*Multiplier
+}
+
+func NewEightBitMultiplier(byte1, byte2 string) (*EightBitMultiplier, error) {
+	m, err := NewMultiplier(8, byte1, byte2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EightBitMultiplier{m}, nil
+}
+
+func NewSignedEightBitMultiplier(byte1, byte2 string) (*EightBitMultiplier, error) {
+	m, err := NewSignedMultiplier(8, byte1, byte2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EightBitMultiplier{m}, nil
+}
+
+// 16-bit Multiplier
+
+type SixteenBitMultiplier struct {
+	*Multiplier
+}
+
+func NewSixteenBitMultiplier(bytes1, bytes2 string) (*SixteenBitMultiplier, error) {
+	m, err := NewMultiplier(16, bytes1, bytes2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SixteenBitMultiplier{m}, nil
+}
+
+func NewSignedSixteenBitMultiplier(bytes1, bytes2 string) (*SixteenBitMultiplier, error) {
+	m, err := NewSignedMultiplier(16, bytes1, bytes2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SixteenBitMultiplier{m}, nil
+}
@@ -0,0 +1,58 @@
+package circuit
+
+// 2:1 Multiplexer
+// Picks one of two inputs to pass along, depending on the select line
+// sel   out
+// 0     in0
+// 1     in1
+//
+// The inverted select line is passed in too, so a whole row of multiplexers switched together can share a single inverter
+
+type mux2 struct {
+	in0And *andGate
+	in1And *andGate
+	orGate *orGate
+}
+
+func newMux2(in0, in1, sel, selInv emitter) *mux2 {
+	m := &mux2{}
+
+	m.in0And = newANDGate(in0, selInv)
+	m.in1And = newANDGate(in1, sel)
+	m.orGate = newORGate(m.in0And, m.in1And)
+
+	return m
+}
+
+func (m *mux2) ports() []namedPart {
+	return []namedPart{
+		{"in0", portOf(m.in0And, "pin1")},
+		{"in1", portOf(m.in1And, "pin1")},
+		{"sel", portOf(m.in1And, "pin2")},
+		{"out", m},
+	}
+}
+
+func (m *mux2) parts() []namedPart {
+	return []namedPart{
+		{"in0And", m.in0And},
+		{"in1And", m.in1And},
+		{"orGate", m.orGate},
+	}
+}
+
+func (m *mux2) source() *pwrSource {
+	return m.orGate.source()
+}
+
+func (m *mux2) WireUp(ch func(Signal)) {
+	m.orGate.WireUp(ch)
+}
+
+func (m *mux2) Emitting() bool {
+	return m.orGate.Emitting()
+}
+
+func (m *mux2) Signal() Signal {
+	return m.orGate.Signal()
+}
@@ -0,0 +1,198 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// Operands
+// Anywhere a string of bits goes in, a number literal can go in instead and is turned into the bits for it
+// 0x2A, 0X2a     hexadecimal
+// 0o52, 0O52     octal
+// 0b101010       binary
+// 0d42, 42       decimal (signed, e.g. -42 or +42, with negatives going in as two's complement)
+// 0xFF_FF, 1_000 underscores can separate digits (like Go's own literals)
+//
+// A string of nothing but 0s and 1s is still read as the bits themselves, so a decimal number written only with 0s and 1s needs its 0d (or a sign), e.g.
+// 10 is the bits 10 but 0d10 or +10 is ten
+
+var operandPrefixes = map[string]int{"0x": 16, "0o": 8, "0b": 2, "0d": 10}
+
+var operandDigits = map[int]string{16: "[0-9a-f]", 8: "[0-7]", 2: "[01]", 10: "[0-9]"}
+
+var operandBaseNames = map[int]string{16: "hexadecimal", 8: "octal", 2: "binary", 10: "decimal"}
+
+var bitsPattern = regexp.MustCompile("^[01]+$")
+var decimalPattern = regexp.MustCompile("^[+-]?[0-9]+(_[0-9]+)*$")
+
+// ParseOperand turns a number literal (see Operands) into a string of exactly width 0s and 1s, or errors if it isn't one or doesn't fit
+func ParseOperand(operand string, width int) (string, error) {
+	bits, err := parseOperand(operand, width)
+	if err != nil {
+		return "", err
+	}
+
+	if !bitsPattern.MatchString(bits) || len(bits) != width {
+		return "", errors.New(fmt.Sprintf("Operand not a %d-bit number: %s", width, operand))
+	}
+
+	return bits, nil
+}
+
+// parseOperand turns a number literal into width bits, but hands back anything that isn't a number literal (e.g. a string of bits) untouched, leaving it
+// for whatever validates the bits to complain about
+func parseOperand(operand string, width int) (string, error) {
+	value, _, ok, err := operandValue(operand)
+	if !ok || err != nil {
+		return operand, err
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(width)) // 2^width
+
+	if value.Sign() < 0 {
+		if new(big.Int).Neg(value).Cmp(new(big.Int).Rsh(limit, 1)) > 0 {
+			return "", errors.New(fmt.Sprintf("Operand %s does not fit in %d bits", operand, width))
+		}
+
+		value.Add(value, limit) // two's complement
+	}
+
+	if value.Cmp(limit) >= 0 {
+		return "", errors.New(fmt.Sprintf("Operand %s does not fit in %d bits", operand, width))
+	}
+
+	return fmt.Sprintf("%0*s", width, value.Text(2)), nil
+}
+
+// parseOperandPair parses both inputs of a two input circuit, see parseOperand
+func parseOperandPair(width int, operand1, operand2 string) (string, string, error) {
+	bits1, err := parseOperand(operand1, width)
+	if err != nil {
+		return "", "", err
+	}
+
+	bits2, err := parseOperand(operand2, width)
+	if err != nil {
+		return "", "", err
+	}
+
+	return bits1, bits2, nil
+}
+
+// parseSizedOperand is parseOperand for circuits as wide as their input, where hexadecimal, octal and binary literals are as wide as their digits
+// (e.g. 0x0F is 8 bits), but a decimal number doesn't say how wide it is
+func parseSizedOperand(operand string) (string, error) {
+	_, base, ok, err := operandValue(operand)
+	if !ok || err != nil {
+		return operand, err
+	}
+
+	if base == 10 {
+		return "", errors.New(fmt.Sprintf("Decimal operand %s does not say how many bits it is, use 0x, 0o or 0b instead", operand))
+	}
+
+	digits := strings.Replace(operand[2:], "_", "", -1)
+
+	return parseOperand(operand, len(digits)*map[int]int{16: 4, 8: 3, 2: 1}[base])
+}
+
+// operandValue reads a number literal, ok being false when it isn't one at all (including strings of bits, which are left as they are)
+func operandValue(operand string) (value *big.Int, base int, ok bool, err error) {
+	if bitsPattern.MatchString(operand) {
+		return nil, 0, false, nil
+	}
+
+	lower := strings.ToLower(operand)
+	digits := ""
+
+	if len(lower) > 2 && operandPrefixes[lower[:2]] != 0 {
+		base = operandPrefixes[lower[:2]]
+		digits = lower[2:]
+
+		match, _ := regexp.MatchString(fmt.Sprintf("^_?%s+(_%s+)*$", operandDigits[base], operandDigits[base]), digits)
+		if !match {
+			return nil, base, true, errors.New(fmt.Sprintf("Operand not in %s format: %s", operandBaseNames[base], operand))
+		}
+	} else if decimalPattern.MatchString(operand) {
+		base = 10
+		digits = operand
+	} else {
+		return nil, 0, false, nil
+	}
+
+	value, _ = new(big.Int).SetString(strings.Replace(digits, "_", "", -1), base)
+
+	return value, base, true, nil
+}
+
+type NumberFormat int
+
+const (
+	Binary        NumberFormat = iota // the bits as they are, e.g. 11111110
+	Hexadecimal                       // e.g. 0xFE
+	Octal                             // e.g. 0o376
+	Decimal                           // read unsigned, e.g. 254
+	SignedDecimal                     // read as two's complement, e.g. -2
+)
+
+var numberFormatNames = []string{"bin", "hex", "oct", "dec", "signed"}
+
+func (f NumberFormat) String() string {
+	if f < 0 || int(f) >= len(numberFormatNames) {
+		return fmt.Sprintf("NumberFormat(%d)", int(f))
+	}
+
+	return numberFormatNames[f]
+}
+
+// ParseNumberFormat finds a NumberFormat by its name: bin, hex, oct, dec or signed
+func ParseNumberFormat(name string) (NumberFormat, error) {
+	for i, n := range numberFormatNames {
+		if n == name {
+			return NumberFormat(i), nil
+		}
+	}
+
+	return Binary, errors.New(fmt.Sprintf("Unknown number format: %s (e.g. %s)", name, strings.Join(numberFormatNames, "/")))
+}
+
+// FormatBits writes a string of bits (e.g. the String of an adder) in another number format.  Hexadecimal and octal keep all the digits the bits need,
+// e.g. 8 bits of 0s is 0x00
+func FormatBits(bits string, format NumberFormat) (string, error) {
+	if !bitsPattern.MatchString(bits) {
+		return "", errors.New(fmt.Sprint("Input bits not in binary format: " + bits))
+	}
+
+	value, _ := new(big.Int).SetString(bits, 2)
+
+	switch format {
+	case Binary:
+		return bits, nil
+	case Hexadecimal:
+		return fmt.Sprintf("0x%0*s", (len(bits)+3)/4, strings.ToUpper(value.Text(16))), nil
+	case Octal:
+		return fmt.Sprintf("0o%0*s", (len(bits)+2)/3, value.Text(8)), nil
+	case Decimal:
+		return value.String(), nil
+	case SignedDecimal:
+		if bits[0] == '1' {
+			value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(bits))))
+		}
+		return value.String(), nil
+	}
+
+	return "", errors.New(fmt.Sprint("Unknown number format: ", format))
+}
+
+// Format writes the bits on the Bus in another number format (X or Z bits can't be, so the bits are left as they are)
+func (b *Bus) Format(format NumberFormat) string {
+	s, err := FormatBits(b.String(), format)
+	if err != nil {
+		return b.String()
+	}
+
+	return s
+}
@@ -5,8 +5,13 @@ import (
 	"time"
 )
 
+// oscillator alternates its state on its own goroutine, but only hands each change to its subscribers when the goroutine that owns the circuit asks for it
+// (via Deliver or AwaitTick), since nothing else in a circuit is safe to touch from two goroutines at once
 type oscillator struct {
+	pwrSource
 	stopCh chan bool
+	doneCh chan struct{}
+	ticks  chan Signal // holds only the latest tick not yet delivered
 	emit   atomic.Value
 }
 
@@ -14,7 +19,10 @@ func newOscillator(init bool) *oscillator {
 	o := &oscillator{}
 
 	o.stopCh = make(chan bool)
+	o.doneCh = make(chan struct{})
+	o.ticks = make(chan Signal, 1)
 	o.emit.Store(init)
+	o.signal = boolSignal(init)
 
 	return o
 }
@@ -22,28 +30,67 @@ func newOscillator(init bool) *oscillator {
 func (o *oscillator) Oscillate(hertz int) {
 
 	go func() {
+		defer close(o.doneCh)
+
 		t := time.NewTicker(time.Second / time.Duration(hertz))
 		for {
 			select {
 			case <-t.C:
 				b, _ := o.emit.Load().(bool)
 				o.emit.Store(!b)
+
+				// a tick nobody has delivered yet is out of date now, so swap it for this one
+				select {
+				case <-o.ticks:
+				default:
+				}
+				o.ticks <- boolSignal(!b)
 			case <-o.stopCh:
 				t.Stop()
-				break
+				return // a plain break only leaves the select, leaving the goroutine ticking forever
 			}
 		}
 	}()
 }
 
+// Deliver passes the latest tick (if there's been one since the last delivery) on to the subscribers, returning whether there was one
+func (o *oscillator) Deliver() bool {
+	select {
+	case sig := <-o.ticks:
+		o.transmit(sig)
+		return true
+	default:
+		return false
+	}
+}
+
+// AwaitTick waits for the next tick and delivers it to the subscribers, returning the signal delivered (or the last one, once stopped)
+func (o *oscillator) AwaitTick() Signal {
+	select {
+	case sig := <-o.ticks:
+		o.transmit(sig)
+	case <-o.doneCh:
+	}
+
+	return o.Delivered()
+}
+
 func (o *oscillator) Stop() {
 	o.stopCh <- true
 }
 
+// Emitting is the state the oscillator is ticking at right now, which its subscribers only see once it's delivered (see Delivered)
 func (o *oscillator) Emitting() bool {
 	b, _ := o.emit.Load().(bool)
 	return b
 }
 
+// Signal is Emitting as a Signal, so likewise not necessarily delivered yet
+func (o *oscillator) Signal() Signal {
+	return boolSignal(o.Emitting())
+}
 
-
+// Delivered is the signal last handed to the subscribers, i.e. what the wired up circuit is being driven by
+func (o *oscillator) Delivered() Signal {
+	return o.pwrSource.Signal()
+}
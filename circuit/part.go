@@ -0,0 +1,196 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// namedPart is one piece of a larger component, named the same as the field that holds it (with an index if the field holds several)
+type namedPart struct {
+	name string
+	part interface{}
+}
+
+// assembly is implemented by anything built out of smaller parts (gates out of relays, adders out of gates, etc)
+type assembly interface {
+	parts() []namedPart
+}
+
+// pinned is implemented by anything with named input and output pins.  Unlike parts, an input pin belongs to whatever drives it, so walking the ports never
+// wanders outside of the component (e.g. when taking a census)
+type pinned interface {
+	ports() []namedPart
+}
+
+// portOf returns the named port of a part, or nil if it has no such port
+func portOf(part interface{}, name string) interface{} {
+	if p, ok := part.(pinned); ok {
+		for _, port := range p.ports() {
+			if port.name == name {
+				return port.part
+			}
+		}
+	}
+
+	return nil
+}
+
+// findPart walks down through the parts (and ports) of a component by a dotted path such as "fullAdders[3].halfAdder2.carry"
+func findPart(root interface{}, path string) (interface{}, error) {
+	current := root
+
+	for _, name := range strings.Split(path, ".") {
+		a, ok := current.(assembly)
+		p, hasPorts := current.(pinned)
+		if !ok && !hasPorts {
+			return nil, errors.New(fmt.Sprint("Cannot find " + name + " inside a part with no parts of its own: " + path))
+		}
+
+		var candidates []namedPart
+		if ok {
+			candidates = append(candidates, a.parts()...)
+		}
+		if hasPorts {
+			candidates = append(candidates, p.ports()...)
+		}
+
+		found := false
+		for _, c := range candidates {
+			if c.name == name {
+				current = c.part
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, errors.New(fmt.Sprint("No part named " + name + " in path: " + path))
+		}
+	}
+
+	return current, nil
+}
+
+// wire is implemented by anything whose signal is held by a pwrSource that can be reached (e.g. to inject a fault)
+type wire interface {
+	source() *pwrSource
+}
+
+func (p *pwrSource) source() *pwrSource {
+	return p
+}
+
+// sourceOf returns the pwrSource behind an emitter, or nil if there isn't one (e.g. a Battery)
+func sourceOf(e interface{}) *pwrSource {
+	if w, ok := e.(wire); ok {
+		return w.source()
+	}
+	return nil
+}
+
+// prefixParts renames parts found inside an inner component so they can be found from the outer one (e.g. "carryOut" becomes "adder.carryOut")
+func prefixParts(prefix string, parts []namedPart) []namedPart {
+	prefixed := make([]namedPart, len(parts))
+
+	for i, p := range parts {
+		prefixed[i] = namedPart{prefix + "." + p.name, p.part}
+	}
+
+	return prefixed
+}
+
+// Probe reads the signal anywhere inside a circuit, found by a dotted path of part and port names such as "fullAdders[3].halfAdder2.carry"
+func Probe(circuit assembly, path string) (Signal, error) {
+	part, err := findPart(circuit, path)
+	if err != nil {
+		return X, err
+	}
+
+	if part == nil {
+		return Low, nil // an unconnected (nil) pin has always meant no power
+	}
+
+	e, ok := part.(emitter)
+	if !ok {
+		return X, errors.New(fmt.Sprint("Cannot probe a part that is not a wire: " + path))
+	}
+
+	return signalOf(e), nil
+}
+
+// Ports lists the names of the pins (inputs and outputs) of the part at the path (or of the circuit itself if the path is empty)
+func Ports(circuit assembly, path string) ([]string, error) {
+	part, err := partAt(circuit, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if p, ok := part.(pinned); ok {
+		for _, port := range p.ports() {
+			names = append(names, port.name)
+		}
+	}
+
+	return names, nil
+}
+
+// Parts lists the names of the parts inside the part at the path (or of the circuit itself if the path is empty)
+func Parts(circuit assembly, path string) ([]string, error) {
+	part, err := partAt(circuit, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if a, ok := part.(assembly); ok {
+		for _, p := range a.parts() {
+			names = append(names, p.name)
+		}
+	}
+
+	return names, nil
+}
+
+// Paths lists the path to every wire that can be probed inside the circuit, all the way down to the relay contacts
+func Paths(circuit assembly) []string {
+	var paths []string
+	seen := map[string]bool{}
+
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+
+	var walk func(prefix string, part interface{})
+	walk = func(prefix string, part interface{}) {
+		if p, ok := part.(pinned); ok {
+			for _, port := range p.ports() {
+				add(prefix + port.name)
+			}
+		}
+
+		if a, ok := part.(assembly); ok {
+			for _, p := range a.parts() {
+				if _, ok := p.part.(emitter); ok {
+					add(prefix + p.name)
+				}
+				walk(prefix+p.name+".", p.part)
+			}
+		}
+	}
+
+	walk("", circuit)
+
+	return paths
+}
+
+func partAt(circuit assembly, path string) (interface{}, error) {
+	if path == "" {
+		return circuit, nil
+	}
+	return findPart(circuit, path)
+}
@@ -1,17 +1,45 @@
 package circuit
 
+import "time"
+
 type relay struct {
 	aIn       emitter
 	bIn       emitter
-	openOut   emitter
-	closedOut emitter
+	openOut   *xContact
+	closedOut *andContact
 }
 
 func newRelay(pin1, pin2 emitter) *relay {
-	return &relay{
+	r := &relay{
 		pin1,
 		pin2,
 		newXContact(pin1, pin2),
 		newANDContact(pin1, pin2),
 	}
+
+	r.setDelay(DefaultRelayDelay)
+
+	return r
+}
+
+func (r *relay) ports() []namedPart {
+	return []namedPart{
+		{"aIn", r.aIn},
+		{"bIn", r.bIn},
+		{"openOut", r.openOut},
+		{"closedOut", r.closedOut},
+	}
+}
+
+func (r *relay) parts() []namedPart {
+	return []namedPart{
+		{"openOut", r.openOut},
+		{"closedOut", r.closedOut},
+	}
+}
+
+// setDelay sets how long the relay takes to switch its contacts while a Simulation is running
+func (r *relay) setDelay(d time.Duration) {
+	r.openOut.delay = d
+	r.closedOut.delay = d
 }
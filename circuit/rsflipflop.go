@@ -9,41 +9,58 @@ import "errors"
 // 1 0   0   1
 // 0 0   q  !q  (hold)
 // 1 1   x   x  (invalid)
+//
+// Q starts out as X (unknown) until the flip-flop is first set or reset.  Releasing both inputs at the same instant after they were both powered leaves the
+// NOR gates racing each other forever, so Q goes X then too
 
 type rsFlipFlop struct {
-	rIn          emitter
-	sIn          emitter
-	rNor         *norGate
-	sNor         *norGate
-	sNorTempPin2 emitter // to avoid a direct link between rNor's output as sNor's input (recursion!)
+	rIn   *jumper
+	sIn   *jumper
+	rNor  *norGate
+	sNor  *norGate
+	rLoop *jumper // rNor's output (Q) fed back into sNor
+	sLoop *jumper // sNor's output (!Q) fed back into rNor
 }
 
 func newRSFlipFLop(rPin, sPin emitter) (*rsFlipFlop, error) {
-	f := &rsFlipFlop{}
-
-	if err := f.updateInputs(rPin, sPin); err != nil {
+	if err := validateRSInputs(rPin, sPin); err != nil {
 		return nil, err
 	}
 
-	f.sNorTempPin2 = nil // due to recursion, nil'ing the link from rNor's output.  qEmitting() will handle the rest.
+	f := &rsFlipFlop{
+		rIn:   newJumper(Low),
+		sIn:   newJumper(Low),
+		rLoop: newJumper(X),
+		sLoop: newJumper(X),
+	}
+
+	f.rNor = newNORGate(f.rIn, f.sLoop)
+	f.sNor = newNORGate(f.sIn, f.rLoop)
+
+	Simultaneously(func() {
+		f.rLoop.connect(f.rNor)
+		f.sLoop.connect(f.sNor)
+	})
 
-	f.setupNors()
+	f.updateInputs(rPin, sPin)
 
 	return f, nil
 }
 
 func (f *rsFlipFlop) updateInputs(rPin, sPin emitter) error {
-	if err := f.validateInputs(rPin, sPin); err != nil {
+	if err := validateRSInputs(rPin, sPin); err != nil {
 		return err
 	}
 
-	f.rIn = rPin
-	f.sIn = sPin
+	Simultaneously(func() {
+		f.rIn.connect(rPin)
+		f.sIn.connect(sPin)
+	})
 
 	return nil
 }
 
-func (f *rsFlipFlop) validateInputs(rPin, sPin emitter) error {
+func validateRSInputs(rPin, sPin emitter) error {
 	if (rPin != nil && rPin.Emitting()) && (sPin != nil && sPin.Emitting()) {
 		return errors.New("Both inputs of a Flip-Flop cannot be powered simultaneously")
 	}
@@ -51,32 +68,46 @@ func (f *rsFlipFlop) validateInputs(rPin, sPin emitter) error {
 	return nil
 }
 
-func (f *rsFlipFlop) setupNors() {
-	f.sNor = newNORGate(f.sIn, f.sNorTempPin2)
-	f.rNor = newNORGate(f.rIn, f.sNor)
+func (f *rsFlipFlop) ports() []namedPart {
+	return []namedPart{
+		{"rIn", f.rIn.in},
+		{"sIn", f.sIn.in},
+		{"q", f.rNor},
+		{"qBar", f.sNor},
+	}
 }
 
-func (f *rsFlipFlop) qEmitting() (bool, error) {
+func (f *rsFlipFlop) parts() []namedPart {
+	return []namedPart{
+		{"rNor", f.rNor},
+		{"sNor", f.sNor},
+		{"rLoop", f.rLoop},
+		{"sLoop", f.sLoop},
+	}
+}
 
-	if err := f.validateInputs(f.rIn, f.sIn); err != nil {
-		return false, err
+func (f *rsFlipFlop) qSignal() (Signal, error) {
+	if err := validateRSInputs(f.rIn, f.sIn); err != nil {
+		return X, err
 	}
 
-	f.setupNors()
+	return f.rNor.Signal(), nil
+}
 
-	if f.rNor.Emitting() {
-		f.sNorTempPin2 = &Battery{}
-		return true, nil
-	} else {
-		f.sNorTempPin2 = nil
-		return false, nil
+func (f *rsFlipFlop) qBarSignal() (Signal, error) {
+	if err := validateRSInputs(f.rIn, f.sIn); err != nil {
+		return X, err
 	}
+
+	return f.sNor.Signal(), nil
+}
+
+func (f *rsFlipFlop) qEmitting() (bool, error) {
+	q, err := f.qSignal()
+	return q == High, err
 }
 
 func (f *rsFlipFlop) qBarEmitting() (bool, error) {
-	if qEmitting, err := f.qEmitting(); err != nil {
-		return !qEmitting, err
-	} else {
-		return !qEmitting, nil
-	}
+	qBar, err := f.qBarSignal()
+	return qBar == High, err
 }
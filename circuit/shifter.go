@@ -0,0 +1,351 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+type ShiftOp int
+
+const (
+	ShiftLeft            ShiftOp = iota // 0s fill in from the right
+	ShiftRight                          // 0s fill in from the left
+	ShiftRightArithmetic                // copies of the sign bit fill in from the left, so a signed number is halved (rounding down) per place
+	RotateLeft                          // bits shifted out the left come back in on the right
+	RotateRight                         // bits shifted out the right come back in on the left
+)
+
+func (o ShiftOp) String() string {
+	switch o {
+	case ShiftLeft:
+		return "shift left"
+	case ShiftRight:
+		return "shift right"
+	case ShiftRightArithmetic:
+		return "arithmetic shift right"
+	case RotateLeft:
+		return "rotate left"
+	case RotateRight:
+		return "rotate right"
+	default:
+		return fmt.Sprintf("ShiftOp(%d)", int(o))
+	}
+}
+
+// Barrel Shifter
+// One stage per bit of the shift amount, with stage s shifting by 2^s places (or not) depending on that bit, so any amount takes the same few stages.
+// Every stage is just a row of 2:1 multiplexers picking, for each bit, either the bit itself or its neighbor 2^s places away
+//
+// The carry out is the last bit shifted (or rotated) out, or 0 when not shifting at all
+//    10110011
+// << 011
+// =  10011000   carry out 1
+
+type BarrelShifter struct {
+	op             ShiftOp
+	bitsSwitches   *Bus // only when built from bit strings, otherwise the inputs belong to whatever drives them
+	amountSwitches *Bus
+	bits           *Bus
+	amount         *Bus
+	stages         []*shiftStage // stages[s] shifts by 2^s places
+}
+
+// shiftStage shifts (or passes straight through) every bit, along with the carry, by a single power of two
+type shiftStage struct {
+	selInv *inverter
+	muxes  []*mux2
+	carry  *mux2
+}
+
+// NewBarrelShifter builds a shifter for a string of width 0s and 1s (at least 1), shifted by a string of 0s and 1s just wide enough to shift by any
+// amount up to width-1 (e.g. 3 bits for 8 bits)
+func NewBarrelShifter(op ShiftOp, width int, bits, amount string) (*BarrelShifter, error) {
+	if err := validateShiftOp(op); err != nil {
+		return nil, err
+	}
+
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Shifter width must be at least 1, but got ", width))
+	}
+	amountWidth := shiftAmountWidth(width)
+
+	bits, err := parseOperand(bits, width)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err = parseOperand(amount, amountWidth)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBits(width, bits, bits); err != nil {
+		return nil, err
+	}
+
+	match, err := regexp.MatchString(fmt.Sprintf("^[01]{%d}$", amountWidth), amount)
+	if err != nil {
+		return nil, err
+	}
+	if !match {
+		return nil, errors.New(fmt.Sprintf("Shift amount not in %d-bit binary format: %s", amountWidth, amount))
+	}
+
+	s := &BarrelShifter{}
+
+	s.bitsSwitches = newSwitchBusFromBits(bits)
+	s.amountSwitches = newSwitchBusFromBits(amount)
+
+	s.build(op, s.bitsSwitches, s.amountSwitches)
+
+	return s, nil
+}
+
+// NewBarrelShifterFromBuses builds a shifter wired directly to a Bus of bits and a Bus holding the shift amount, so it follows any change on either
+func NewBarrelShifterFromBuses(op ShiftOp, bits, amount *Bus) (*BarrelShifter, error) {
+	if err := validateShiftOp(op); err != nil {
+		return nil, err
+	}
+
+	if bits.Width() < 1 {
+		return nil, errors.New(fmt.Sprint("Shifter width must be at least 1, but got ", bits.Width()))
+	}
+
+	if amount.Width() < 1 {
+		return nil, errors.New(fmt.Sprint("Shift amount width must be at least 1, but got ", amount.Width()))
+	}
+
+	s := &BarrelShifter{}
+
+	s.build(op, bits, amount)
+
+	return s, nil
+}
+
+// shiftAmountWidth is how many bits it takes to shift width bits by anything up to width-1 places (always at least the one)
+func shiftAmountWidth(width int) int {
+	n := 1
+	for 1<<uint(n) < width {
+		n++
+	}
+
+	return n
+}
+
+func validateShiftOp(op ShiftOp) error {
+	if op < ShiftLeft || op > RotateRight {
+		return errors.New(fmt.Sprint("Unknown shift operation: ", op))
+	}
+
+	return nil
+}
+
+func (s *BarrelShifter) build(op ShiftOp, bits, amount *Bus) {
+	n := bits.Width()
+
+	s.op = op
+	s.bits = bits
+	s.amount = amount
+
+	in := bits.wires
+	var carry emitter // nothing shifted out yet
+
+	for st := 0; st < amount.Width(); st++ {
+		d := 1 << uint(st)
+		sel := amount.wires[amount.Width()-1-st] // the rightmost bit of the amount is the 1s place
+
+		stage := &shiftStage{selInv: newInverter(sel)}
+
+		// where bit i comes from when this stage shifts, and which bit is the last one out of it (nil being a 0 filling in)
+		var from func(i int) emitter
+		var out emitter
+
+		switch op {
+		case ShiftLeft:
+			from = func(i int) emitter { return at(in, i+d) }
+			out = at(in, d-1)
+		case ShiftRight:
+			from = func(i int) emitter { return at(in, i-d) }
+			out = at(in, n-d)
+		case ShiftRightArithmetic:
+			sign := in[0]
+			from = func(i int) emitter {
+				if i-d < 0 {
+					return sign
+				}
+				return in[i-d]
+			}
+			out = sign
+			if n-d >= 0 {
+				out = in[n-d]
+			}
+		case RotateLeft:
+			from = func(i int) emitter { return in[(i+d)%n] }
+			out = in[(d-1)%n]
+		default:
+			from = func(i int) emitter { return in[((i-d)%n+n)%n] }
+			out = in[((n-d)%n+n)%n]
+		}
+
+		shifted := make([]emitter, n)
+		for i := range in {
+			m := newMux2(in[i], from(i), sel, stage.selInv)
+			stage.muxes = append(stage.muxes, m)
+			shifted[i] = m
+		}
+
+		stage.carry = newMux2(carry, out, sel, stage.selInv)
+		carry = stage.carry
+
+		s.stages = append(s.stages, stage)
+		in = shifted
+	}
+}
+
+// at is the wire at index i, or nil (a 0) when i falls off either end
+func at(wires []emitter, i int) emitter {
+	if i < 0 || i >= len(wires) {
+		return nil
+	}
+
+	return wires[i]
+}
+
+func (st *shiftStage) ports() []namedPart {
+	ports := []namedPart{{"sel", portOf(st.selInv, "in")}}
+
+	for i, m := range st.muxes {
+		ports = append(ports, namedPart{fmt.Sprintf("out[%d]", i), m})
+	}
+
+	return append(ports, namedPart{"carry", st.carry})
+}
+
+func (st *shiftStage) parts() []namedPart {
+	parts := []namedPart{{"selInv", st.selInv}}
+
+	for i, m := range st.muxes {
+		parts = append(parts, namedPart{fmt.Sprintf("muxes[%d]", i), m})
+	}
+
+	return append(parts, namedPart{"carry", st.carry})
+}
+
+// Op is the kind of shift (or rotate) the shifter does
+func (s *BarrelShifter) Op() ShiftOp {
+	return s.op
+}
+
+// UpdateInputs flips the input switches to match the new bits and shift amount, so the existing shifter settles on the new answer without being rebuilt
+func (s *BarrelShifter) UpdateInputs(bits, amount string) error {
+	if s.bitsSwitches == nil {
+		return errors.New("Shifter inputs are driven by Buses, not switches, so they cannot be updated")
+	}
+
+	bits, err := parseOperand(bits, s.bits.Width())
+	if err != nil {
+		return err
+	}
+
+	amount, err = parseOperand(amount, s.amount.Width())
+	if err != nil {
+		return err
+	}
+
+	if err := validateBits(s.bits.Width(), bits, bits); err != nil {
+		return err
+	}
+
+	match, err := regexp.MatchString(fmt.Sprintf("^[01]{%d}$", s.amount.Width()), amount)
+	if err != nil {
+		return err
+	}
+	if !match {
+		return errors.New(fmt.Sprintf("Shift amount not in %d-bit binary format: %s", s.amount.Width(), amount))
+	}
+
+	Simultaneously(func() {
+		s.bitsSwitches.setBits(bits)
+		s.amountSwitches.setBits(amount)
+	})
+
+	return nil
+}
+
+// Result returns the shifted bits as a Bus that can be wired into other circuits
+func (s *BarrelShifter) Result() *Bus {
+	if len(s.stages) == 0 {
+		return NewBus(s.bits.wires...)
+	}
+
+	b := &Bus{}
+
+	for _, m := range s.stages[len(s.stages)-1].muxes {
+		b.wires = append(b.wires, m)
+	}
+
+	return b
+}
+
+// CarryOut returns the last bit shifted (or rotated) out, so it can be wired into other circuits
+func (s *BarrelShifter) CarryOut() emitter {
+	if len(s.stages) == 0 {
+		return nil
+	}
+
+	return s.stages[len(s.stages)-1].carry
+}
+
+func (s *BarrelShifter) ports() []namedPart {
+	var ports []namedPart
+
+	for i, w := range s.bits.wires {
+		ports = append(ports, namedPart{fmt.Sprintf("bits[%d]", i), w})
+	}
+
+	for i, w := range s.amount.wires {
+		ports = append(ports, namedPart{fmt.Sprintf("amount[%d]", i), w})
+	}
+
+	for i, w := range s.Result().wires {
+		ports = append(ports, namedPart{fmt.Sprintf("result[%d]", i), w})
+	}
+
+	return append(ports, namedPart{"carryOut", s.CarryOut()})
+}
+
+func (s *BarrelShifter) parts() []namedPart {
+	var parts []namedPart
+
+	if s.bitsSwitches != nil {
+		for i, w := range s.bitsSwitches.wires {
+			parts = append(parts, namedPart{fmt.Sprintf("bitsSwitches[%d]", i), w})
+		}
+
+		for i, w := range s.amountSwitches.wires {
+			parts = append(parts, namedPart{fmt.Sprintf("amountSwitches[%d]", i), w})
+		}
+	}
+
+	for i, st := range s.stages {
+		parts = append(parts, namedPart{fmt.Sprintf("stages[%d]", i), st})
+	}
+
+	return parts
+}
+
+func (s *BarrelShifter) outputs() []namedPart {
+	var parts []namedPart
+
+	for i, w := range s.Result().wires {
+		parts = append(parts, namedPart{fmt.Sprintf("result[%d]", i), w})
+	}
+
+	return append(parts, namedPart{"carryOut", s.CarryOut()})
+}
+
+// String is the shifted bits
+func (s *BarrelShifter) String() string {
+	return s.Result().String()
+}
@@ -0,0 +1,91 @@
+package circuit
+
+// Signal is the four-valued state of a wire.  Emitting() only reports true for High, so callers that only care about power can ignore Z and X entirely
+type Signal byte
+
+const (
+	Low  Signal = iota // 0 (no power)
+	High               // 1 (powered)
+	Z                  // undriven (floating)
+	X                  // unknown (contention, invalid state, or fed by Z/X)
+)
+
+func (s Signal) String() string {
+	switch s {
+	case Low:
+		return "0"
+	case High:
+		return "1"
+	case Z:
+		return "Z"
+	default:
+		return "X"
+	}
+}
+
+func boolSignal(b bool) Signal {
+	if b {
+		return High
+	}
+	return Low
+}
+
+// signalOf treats a nil pin as Low, matching how nil has always meant "no power" in this package
+func signalOf(e emitter) Signal {
+	if e == nil {
+		return Low
+	}
+	return e.Signal()
+}
+
+// andSignals is Low if ANY signal is Low, High if ALL are High, and X otherwise (Z is treated as X, an undriven input can't be trusted)
+func andSignals(sigs ...Signal) Signal {
+	if len(sigs) == 0 {
+		return Low
+	}
+
+	result := High
+	for _, s := range sigs {
+		switch s {
+		case Low:
+			return Low
+		case High:
+		default:
+			result = X
+		}
+	}
+
+	return result
+}
+
+// orSignals is High if ANY signal is High, Low if ALL are Low, and X otherwise
+func orSignals(sigs ...Signal) Signal {
+	if len(sigs) == 0 {
+		return Low
+	}
+
+	result := Low
+	for _, s := range sigs {
+		switch s {
+		case High:
+			return High
+		case Low:
+		default:
+			result = X
+		}
+	}
+
+	return result
+}
+
+// notSignal flips Low and High, anything else becomes X
+func notSignal(s Signal) Signal {
+	switch s {
+	case Low:
+		return High
+	case High:
+		return Low
+	default:
+		return X
+	}
+}
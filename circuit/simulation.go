@@ -0,0 +1,127 @@
+package circuit
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultRelayDelay is how long a newly built relay takes to switch its contacts once a Simulation is running (outside of a Simulation, relays always switch instantly)
+var DefaultRelayDelay = 10 * time.Millisecond
+
+// activeSim is the Simulation currently running, if any.  Only one Simulation can run at a time
+var activeSim *Simulation
+
+// Simulation is a discrete-event scheduler working in simulated time.  While it runs, any component with a delay (e.g. a relay's contacts) schedules its output changes
+// for later instead of pushing them out immediately, which makes ripple, glitches, and feedback loops observable
+type Simulation struct {
+	now         time.Duration
+	lastChange  time.Duration
+	events      eventQueue
+	nextSeq     int
+	transitions []Transition
+}
+
+// Transition records a watched emitter changing to a new signal at a point in simulated time
+type Transition struct {
+	At     time.Duration
+	Name   string
+	Signal Signal
+}
+
+func NewSimulation() *Simulation {
+	return &Simulation{}
+}
+
+// Now returns the current simulated time
+func (s *Simulation) Now() time.Duration {
+	return s.now
+}
+
+// After schedules an action (e.g. flipping a Switch) to occur the given amount of simulated time from now
+func (s *Simulation) After(d time.Duration, action func()) {
+	heap.Push(&s.events, &event{s.now + d, s.nextSeq, action})
+	s.nextSeq++
+}
+
+// Watch records every signal change of the emitter (while the Simulation runs) as a Transition under the given name
+func (s *Simulation) Watch(name string, e emitter) {
+	e.WireUp(func(sig Signal) {
+		if activeSim == s {
+			s.transitions = append(s.transitions, Transition{s.now, name, sig})
+		}
+	})
+}
+
+// Transitions returns all changes recorded for watched emitters, in the order they occurred
+func (s *Simulation) Transitions() []Transition {
+	return s.transitions
+}
+
+// Run processes scheduled events in time order until none are left, returning the simulated time of the last actual signal change (the time the circuit settled).
+// If events are still pending beyond the limit (e.g. an oscillating circuit), Run stops and returns an error
+func (s *Simulation) Run(limit time.Duration) (time.Duration, error) {
+	if activeSim != nil {
+		return s.lastChange, errors.New("Another Simulation is already running")
+	}
+
+	activeSim = s
+	defer func() { activeSim = nil }()
+
+	for s.events.Len() > 0 {
+		if s.events[0].at > limit {
+			return s.lastChange, errors.New(fmt.Sprint("Simulation did not settle within ", limit))
+		}
+
+		e := heap.Pop(&s.events).(*event)
+		s.now = e.at
+		e.action()
+	}
+
+	return s.lastChange, nil
+}
+
+// propagate is how a component with a delay pushes out a new signal.  Outside of a Simulation (or with no delay) it is the same as transmit
+func (p *pwrSource) propagate(newSignal Signal) {
+	if activeSim == nil || p.delay == 0 {
+		p.transmit(newSignal)
+		return
+	}
+
+	sim := activeSim
+	sim.After(p.delay, func() {
+		if p.signal != newSignal {
+			sim.lastChange = sim.now
+		}
+		p.transmit(newSignal)
+	})
+}
+
+type event struct {
+	at     time.Duration
+	seq    int // keeps events scheduled for the same time in the order they were scheduled
+	action func()
+}
+
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].at == q[j].at {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at < q[j].at
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x interface{}) { *q = append(*q, x.(*event)) }
+
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
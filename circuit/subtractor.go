@@ -1,30 +1,173 @@
 package circuit
 
-type EightBitSubtractor struct {
-	adder   *EightBitAdder
-	comp    *onesComplementer
-	signBit emitter
+import (
+	"errors"
+	"fmt"
+)
+
+// N-bit Subtractor
+// Adds the two's complement of the second input to the first (complementing every bit, then adding 1 via the adder's carry in)
+//
+// The carry out of the adder is 1 unless the second input was bigger than the first (read unsigned), in which case the difference had to borrow and
+// wrapped around.  Read signed, the difference overflowed if the carries into and out of the leftmost bit disagree
+//    00000011        00000011
+// -  00000001    +   11111110 (+1)
+// =  00000010    = 1 00000010
+
+type Subtractor struct {
+	switchInputs
+	comp     *onesComplementer
+	adder    *Adder
+	noBorrow emitter // the adder's carry out
+	borrow   *inverter
+	overflow *xorGate
 }
 
-func NewEightBitSubtractor(byte1, byte2 string) (*EightBitSubtractor, error) {
-	s := &EightBitSubtractor{}
+// NewSubtractor builds a subtractor of any width (at least 1 bit), taking the second string of bits away from the first
+func NewSubtractor(width int, bits1, bits2 string) (*Subtractor, error) {
+	if width < 1 {
+		return nil, errors.New(fmt.Sprint("Subtractor width must be at least 1, but got ", width))
+	}
 
-	var err error
-	s.comp, err = NewOnesComplementer([]byte(byte2), &Battery{}) // the battery ensures the compliment occurs since the complimentor can conditional compliment based on that emit
+	bits1, bits2, err := parseOperandPair(width, bits1, bits2)
 	if err != nil {
 		return nil, err
 	}
 
-	s.adder, err = NewEightBitAdder(byte1, s.comp.Complement(), &Battery{}) // the added battery is the "+1" to make the "two's compliment"
-	if err != nil {
+	if err := validateBits(width, bits1, bits2); err != nil {
 		return nil, err
 	}
 
-	s.signBit = s.adder.carryOut
+	s := &Subtractor{}
+
+	s.bits1Switches = newSwitchBusFromBits(bits1)
+	s.bits2Switches = newSwitchBusFromBits(bits2)
+
+	s.build(s.bits1Switches, s.bits2Switches)
+
+	return s, nil
+}
+
+// NewSubtractorFromBuses builds a subtractor as wide as the two Buses, wired directly to them, so it follows any change on them
+func NewSubtractorFromBuses(bus1, bus2 *Bus) (*Subtractor, error) {
+	if bus1.Width() != bus2.Width() {
+		return nil, errors.New(fmt.Sprintf("Inputs are not the same width: %d and %d bits", bus1.Width(), bus2.Width()))
+	}
+
+	if bus1.Width() < 1 {
+		return nil, errors.New(fmt.Sprint("Subtractor width must be at least 1, but got ", bus1.Width()))
+	}
+
+	s := &Subtractor{}
+
+	s.build(bus1, bus2)
 
 	return s, nil
 }
 
-func (s *EightBitSubtractor) String() string {
+func (s *Subtractor) build(bus1, bus2 *Bus) {
+	s.comp = NewOnesComplementerFromBus(bus2, &Battery{})                              // the battery ensures the compliment occurs since the complimentor can conditional compliment based on that emit
+	s.adder = newNamedAdderFromBuses("bits", bus1, s.comp.ComplementBus(), &Battery{}) // the added battery is the "+1" to make the "two's compliment"
+
+	s.noBorrow = s.adder.carryOut
+	s.borrow = newInverter(s.noBorrow)
+
+	var msbCarryIn emitter = &Battery{} // the carry into the leftmost bit, which for a 1-bit subtractor is the "+1" itself
+	if s.Width() > 1 {
+		msbCarryIn = s.adder.fullAdders[1].carry
+	}
+	s.overflow = newXORGate(msbCarryIn, s.adder.carryOut)
+}
+
+// Width is how many bits the subtractor works on
+func (s *Subtractor) Width() int {
+	return s.adder.Width()
+}
+
+// UpdateInputs flips the input switches to match the new bits, so the existing subtractor settles on the new answer without being rebuilt
+func (s *Subtractor) UpdateInputs(bits1, bits2 string) error {
+	return s.updateSwitches("Subtractor", s.Width(), bits1, bits2)
+}
+
+// Difference returns the difference bits (without the adder's carry out) as a Bus that can be wired into other circuits
+func (s *Subtractor) Difference() *Bus {
+	return s.adder.Sum()
+}
+
+// Borrow is true when the second input was bigger than the first (read unsigned), so the difference is negative and has wrapped around
+func (s *Subtractor) Borrow() bool {
+	return s.borrow.Emitting()
+}
+
+// Negative is true when the difference's leftmost bit is 1, so read signed it's below zero (unless it overflowed)
+func (s *Subtractor) Negative() bool {
+	return s.adder.fullAdders[0].sum.Emitting()
+}
+
+// Overflow is true when the difference doesn't fit (read signed), e.g. -128 - 1 in 8 bits
+func (s *Subtractor) Overflow() bool {
+	return s.overflow.Emitting()
+}
+
+func (s *Subtractor) ports() []namedPart {
+	ports := []namedPart{
+		{"noBorrow", s.noBorrow},
+		{"borrow", s.borrow},
+		{"overflow", s.overflow},
+	}
+
+	for i := 0; i < s.Width(); i++ {
+		ports = append(ports, namedPart{fmt.Sprintf("difference[%d]", i), portOf(s.adder, fmt.Sprintf("sum[%d]", i))})
+	}
+
+	return ports
+}
+
+func (s *Subtractor) parts() []namedPart {
+	return append(s.switchParts("bits"),
+		namedPart{"comp", s.comp},
+		namedPart{"adder", s.adder},
+		namedPart{"borrow", s.borrow},
+		namedPart{"overflow", s.overflow})
+}
+
+func (s *Subtractor) outputs() []namedPart {
+	return append(prefixParts("adder", s.adder.outputs()),
+		namedPart{"borrow", s.borrow},
+		namedPart{"overflow", s.overflow})
+}
+
+// String is the raw answer of the inner adder, including its carry out (1 when nothing was borrowed) when it's 1, see Difference, Borrow and Overflow to read it properly
+func (s *Subtractor) String() string {
 	return s.adder.String()
 }
+
+// 8-bit Subtractor
+
+type EightBitSubtractor struct {
+	*Subtractor
+}
+
+func NewEightBitSubtractor(byte1, byte2 string) (*EightBitSubtractor, error) {
+	s, err := NewSubtractor(8, byte1, byte2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EightBitSubtractor{s}, nil
+}
+
+// 16-bit Subtractor
+
+type SixteenBitSubtractor struct {
+	*Subtractor
+}
+
+func NewSixteenBitSubtractor(bytes1, bytes2 string) (*SixteenBitSubtractor, error) {
+	s, err := NewSubtractor(16, bytes1, bytes2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SixteenBitSubtractor{s}, nil
+}
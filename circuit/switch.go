@@ -0,0 +1,29 @@
+package circuit
+
+// Switch is a basic On/Off input which can be flipped over time, letting an already built circuit be driven with new values
+type Switch struct {
+	pwrSource
+}
+
+func NewSwitch(init bool) *Switch {
+	s := &Switch{}
+
+	s.Set(init)
+
+	return s
+}
+
+// Set turns the Switch on or off (anything wired up to it only hears about actual changes)
+func (s *Switch) Set(newState bool) {
+	s.transmit(boolSignal(newState))
+}
+
+// Toggle flips the Switch to the opposite of its current state (a floating Switch toggles on)
+func (s *Switch) Toggle() {
+	s.transmit(boolSignal(!s.Emitting()))
+}
+
+// Float disconnects the Switch so it drives nothing at all (Z), until it is Set or Toggled again
+func (s *Switch) Float() {
+	s.transmit(Z)
+}
@@ -4,38 +4,221 @@ import (
 	"fmt"
 
 	"flag"
+	"strconv"
 
 	"github.concur.com/mparks/adder/circuit"
 )
 
-var actionType = flag.String("action", "add", "Type of action to take against input(s) (e.g. add/sub/comp)")
-var bitLength = flag.Int("bitLen", 8, "The number of bits in math actions (8 or 16)")
-var bitString1 = flag.String("bits1", "00000000", "First string of bits in an action (e.g. 11110000)")
-var bitString2 = flag.String("bits2", "00000000", "Second string of bits in an action that takes two inputs (e.g. 00001111)")
+var actionType = flag.String("action", "add", "Type of action to take against input(s) (e.g. add/sub/mul/div/inc/dec/neg/shl/shr/sar/rol/ror/alu/fadd/comp/census/compare)")
+var bitLength = flag.Int("bitLen", 8, "The number of bits in math actions (8 or 16 for add/sub/mul, or any width for div/inc/dec/neg/shifts/alu/compare)")
+var signed = flag.Bool("signed", false, "Read the bits as signed two's complement in actions that care (e.g. mul)")
+var aluOp = flag.String("op", "ADD", "Operation for the alu action (e.g. ADD/ADC/SUB/SBB/AND/OR/XOR/NOT/CMP/INC/DEC)")
+var carryIn = flag.Bool("carryIn", false, "Carry in for the alu action (only ADC and SBB use it)")
+var decimal = flag.Bool("decimal", false, "Read bits1 and bits2 as decimal numbers and add them in packed BCD, bitLen/4 digits (e.g. -action add -decimal -bits1 49 -bits2 35)")
+var bitString1 = flag.String("bits1", "00000000", "First string of bits in an action (e.g. 11110000), or a number (e.g. 0xF0, 0o360, 0b11110000, 240 or -16)")
+var bitString2 = flag.String("bits2", "00000000", "Second string of bits in an action that takes two inputs (e.g. 00001111), or a number (e.g. 0x0F or 15)")
+var formatName = flag.String("format", "bin", "Number format to show answers in (e.g. bin/hex/oct/dec/signed)")
+
+var format circuit.NumberFormat
 
 func main() {
 	flag.Parse()
 
+	var err error
+	if format, err = circuit.ParseNumberFormat(*formatName); err != nil {
+		fmt.Println("Error:" + err.Error())
+		return
+	}
+
 	executeAdder()
 }
 
+// show writes an answer in the chosen number format
+func show(answer *circuit.Bus) string {
+	return answer.Format(format)
+}
+
 func executeAdder() {
 	switch *actionType {
 	case "add":
+		if *decimal {
+			executeDecimalAdder()
+			return
+		}
+
+		switch *bitLength {
+		case 8:
+			a8, err := circuit.NewEightBitAdder(*bitString1, *bitString2, nil)
+			if err != nil {
+				fmt.Println("Error:" + err.Error())
+			} else {
+				if format == circuit.Binary {
+					fmt.Printf("%10s\n+%9s\n=%9s\n\n", *bitString1, *bitString2, a8)
+				} else {
+					fmt.Printf("%10s\n+%9s\n=%9s\n\nCarry: %t\n", *bitString1, *bitString2, show(a8.Sum()), a8.CarryOut().Emitting())
+				}
+			}
+		case 16:
+			a16, err := circuit.NewSixteenBitAdder(*bitString1, *bitString2, nil)
+			if err != nil {
+				fmt.Println("Error:" + err.Error())
+			} else {
+				if format == circuit.Binary {
+					fmt.Printf("%18s\n+%17s\n=%17s\n\n", *bitString1, *bitString2, a16)
+				} else {
+					fmt.Printf("%18s\n+%17s\n=%17s\n\nCarry: %t\n", *bitString1, *bitString2, show(a16.Sum()), a16.CarryOut().Emitting())
+				}
+			}
+		}
+	case "sub":
+		var s *circuit.Subtractor
+		var err error
+
+		switch *bitLength {
+		case 8:
+			var s8 *circuit.EightBitSubtractor
+			if s8, err = circuit.NewEightBitSubtractor(*bitString1, *bitString2); err == nil {
+				s = s8.Subtractor
+			}
+		case 16:
+			var s16 *circuit.SixteenBitSubtractor
+			if s16, err = circuit.NewSixteenBitSubtractor(*bitString1, *bitString2); err == nil {
+				s = s16.Subtractor
+			}
+		}
+
+		if err != nil {
+			fmt.Println("Error:" + err.Error())
+		} else if s != nil {
+			fmt.Printf("%*s\n-%*s\n=%*s\n\nBorrow: %t\nOverflow: %t\n", *bitLength+2, *bitString1, *bitLength+1, *bitString2, *bitLength+1, show(s.Difference()), s.Borrow(), s.Overflow())
+		}
+	case "mul":
+		var m *circuit.Multiplier
+		var err error
+
+		switch *bitLength {
+		case 8:
+			var m8 *circuit.EightBitMultiplier
+			if *signed {
+				m8, err = circuit.NewSignedEightBitMultiplier(*bitString1, *bitString2)
+			} else {
+				m8, err = circuit.NewEightBitMultiplier(*bitString1, *bitString2)
+			}
+			if err == nil {
+				m = m8.Multiplier
+			}
+		case 16:
+			var m16 *circuit.SixteenBitMultiplier
+			if *signed {
+				m16, err = circuit.NewSignedSixteenBitMultiplier(*bitString1, *bitString2)
+			} else {
+				m16, err = circuit.NewSixteenBitMultiplier(*bitString1, *bitString2)
+			}
+			if err == nil {
+				m = m16.Multiplier
+			}
+		}
+
+		if err != nil {
+			fmt.Println("Error:" + err.Error())
+		} else if m != nil {
+			fmt.Printf("%*s\nx%*s\n=%*s\n\n", *bitLength*2+1, *bitString1, *bitLength*2, *bitString2, *bitLength*2, show(m.Product()))
+		}
+	case "div":
+		d, err := circuit.NewDivider(*bitLength, *bitString1, *bitString2)
+		if err != nil {
+			fmt.Println("Error:" + err.Error())
+		} else if d.DivideByZero() {
+			fmt.Println("Error:Cannot divide by zero")
+		} else {
+			fmt.Printf("%*s\n/%*s\n=%*s\nr%*s\n\n", *bitLength+1, *bitString1, *bitLength, *bitString2, *bitLength, show(d.Quotient()), *bitLength, show(d.Remainder()))
+		}
+	case "inc":
+		i, err := circuit.NewIncrementer(*bitLength, *bitString1)
+		if err != nil {
+			fmt.Println("Error:" + err.Error())
+		} else {
+			fmt.Printf("%s + 1\n= %s\n\nCarry: %t\nOverflow: %t\n", *bitString1, show(i.Result()), i.CarryOut().Emitting(), i.Overflow())
+		}
+	case "dec":
+		d, err := circuit.NewDecrementer(*bitLength, *bitString1)
+		if err != nil {
+			fmt.Println("Error:" + err.Error())
+		} else {
+			fmt.Printf("%s - 1\n= %s\n\nBorrow: %t\nOverflow: %t\n", *bitString1, show(d.Result()), d.BorrowOut().Emitting(), d.Overflow())
+		}
+	case "neg":
+		n, err := circuit.NewNegator(*bitLength, *bitString1)
+		if err != nil {
+			fmt.Println("Error:" + err.Error())
+		} else {
+			fmt.Printf("-%s\n= %s\n\nOverflow: %t\n", *bitString1, show(n.Result()), n.Overflow())
+		}
+	case "shl", "shr", "sar", "rol", "ror":
+		ops := map[string]circuit.ShiftOp{
+			"shl": circuit.ShiftLeft,
+			"shr": circuit.ShiftRight,
+			"sar": circuit.ShiftRightArithmetic,
+			"rol": circuit.RotateLeft,
+			"ror": circuit.RotateRight,
+		}
+
+		s, err := circuit.NewBarrelShifter(ops[*actionType], *bitLength, *bitString1, *bitString2)
+		if err != nil {
+			fmt.Println("Error:" + err.Error())
+		} else {
+			fmt.Printf("%s %s by %s\n= %s\n\nCarry: %t\n", *bitString1, s.Op(), *bitString2, show(s.Result()), s.CarryOut().Emitting())
+		}
+	case "alu":
+		op := circuit.ALUOp(-1)
+		for o := circuit.ADD; o <= circuit.DEC; o++ {
+			if o.String() == *aluOp {
+				op = o
+			}
+		}
+
+		a, err := circuit.NewALU(*bitLength, *bitString1, *bitString2, op, *carryIn)
+		if err != nil {
+			fmt.Println("Error:" + err.Error())
+		} else {
+			fmt.Printf("%*s\n%-4s%*s\n=   %*s\n\nFlags: %s\n", *bitLength+4, *bitString1, op, *bitLength, *bitString2, *bitLength, show(a.Result()), a.Flags())
+		}
+	case "fadd":
+		h, err := circuit.NewHalfPrecisionAdder(*bitString1, *bitString2)
+		if err != nil {
+			fmt.Println("Error:" + err.Error())
+		} else {
+			bits1, _ := circuit.ParseOperand(*bitString1, 16)
+			bits2, _ := circuit.ParseOperand(*bitString2, 16)
+			value1, _ := strconv.ParseUint(bits1, 2, 16)
+			value2, _ := strconv.ParseUint(bits2, 2, 16)
+
+			fmt.Printf(" %s   (%v)\n+%s   (%v)\n=%s   (%v)\n\n", bits1, circuit.Float16ToFloat64(uint16(value1)), bits2, circuit.Float16ToFloat64(uint16(value2)), h, h.Float())
+		}
+	case "census":
 		switch *bitLength {
 		case 8:
 			a8, err := circuit.NewEightBitAdder(*bitString1, *bitString2, nil)
 			if err != nil {
 				fmt.Println("Error:" + err.Error())
 			} else {
-				fmt.Printf("%10s\n+%9s\n=%9s\n\n", *bitString1, *bitString2, a8)
+				fmt.Printf("8-bit Adder\n%s\n", circuit.TakeCensus(a8))
 			}
 		case 16:
 			a16, err := circuit.NewSixteenBitAdder(*bitString1, *bitString2, nil)
 			if err != nil {
 				fmt.Println("Error:" + err.Error())
 			} else {
-				fmt.Printf("%18s\n+%17s\n=%17s\n\n", *bitString1, *bitString2, a16)
+				fmt.Printf("16-bit Adder\n%s\n", circuit.TakeCensus(a16))
+			}
+		}
+	case "compare":
+		reports, err := circuit.CompareAdders(*bitLength)
+		if err != nil {
+			fmt.Println("Error:" + err.Error())
+		} else {
+			for _, r := range reports {
+				fmt.Println(r)
 			}
 		}
 	case "comp":
@@ -48,3 +231,26 @@ func executeAdder() {
 		}
 	}
 }
+
+func executeDecimalAdder() {
+	digits := *bitLength / 4
+
+	bcd1, err := circuit.DecimalToBCD(*bitString1, digits)
+	if err != nil {
+		fmt.Println("Error:" + err.Error())
+		return
+	}
+
+	bcd2, err := circuit.DecimalToBCD(*bitString2, digits)
+	if err != nil {
+		fmt.Println("Error:" + err.Error())
+		return
+	}
+
+	a, err := circuit.NewBCDAdder(bcd1, bcd2, nil)
+	if err != nil {
+		fmt.Println("Error:" + err.Error())
+	} else {
+		fmt.Printf("%*s   (%s)\n+%*s   (%s)\n=%*s   (%s)\n\n", digits+2, *bitString1, bcd1, digits+1, *bitString2, bcd2, digits+1, a.Decimal(), a)
+	}
+}